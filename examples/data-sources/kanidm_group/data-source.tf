@@ -0,0 +1,19 @@
+# Example: Look up a built-in group
+data "kanidm_group" "admins" {
+  id = "idm_admins"
+}
+
+output "admin_members" {
+  description = "Direct members of idm_admins"
+  value       = data.kanidm_group.admins.members
+}
+
+# Example: Use the POSIX gidnumber of an existing group
+data "kanidm_group" "developers" {
+  id = "developers"
+}
+
+output "developers_gid" {
+  description = "POSIX group ID of developers"
+  value       = data.kanidm_group.developers.gidnumber
+}
@@ -0,0 +1,26 @@
+# Example: Audit who effectively has access to an application
+data "kanidm_group_effective_members" "grafana" {
+  group = "grafana-users"
+}
+
+output "grafana_users" {
+  description = "Every account granted access, including through nested groups"
+  value       = data.kanidm_group_effective_members.grafana.member_names
+}
+
+output "grafana_access_paths" {
+  description = "The chain of groups that grants each account access"
+  value = {
+    for member in data.kanidm_group_effective_members.grafana.members :
+    member.name => join(" -> ", member.path)
+  }
+}
+
+# Example: List the groups a person belongs to, including inherited memberships
+data "kanidm_person" "alice" {
+  id = "alice"
+}
+
+output "alice_groups" {
+  value = data.kanidm_person.alice.memberof
+}
@@ -0,0 +1,14 @@
+# Example: All team groups
+data "kanidm_groups" "teams" {
+  name_prefix = "team-"
+}
+
+# Example: POSIX groups only
+data "kanidm_groups" "posix" {
+  class = "posixgroup"
+}
+
+output "posix_group_names" {
+  description = "Names of all POSIX groups"
+  value       = data.kanidm_groups.posix.ids
+}
@@ -0,0 +1,12 @@
+# Example: List every OAuth2 client
+data "kanidm_oauth2_clients" "all" {}
+
+output "oauth2_client_names" {
+  description = "Names of all OAuth2 clients"
+  value       = data.kanidm_oauth2_clients.all.names
+}
+
+# Example: OAuth2 clients for internal tools
+data "kanidm_oauth2_clients" "internal" {
+  name_prefix = "internal-"
+}
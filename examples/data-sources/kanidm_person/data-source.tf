@@ -0,0 +1,14 @@
+# Example: Look up a person created outside Terraform
+data "kanidm_person" "alice" {
+  id = "alice"
+}
+
+output "alice_mail" {
+  description = "Email addresses for Alice"
+  value       = data.kanidm_person.alice.mail
+}
+
+output "alice_uuid" {
+  description = "Immutable UUID of Alice's account"
+  value       = data.kanidm_person.alice.uuid
+}
@@ -0,0 +1,21 @@
+# Example: All members of a group, including nested groups
+data "kanidm_persons" "developers" {
+  member_of = "developers"
+}
+
+# Drive per-user resources in another provider
+resource "github_membership" "developers" {
+  for_each = toset(data.kanidm_persons.developers.ids)
+  username = each.value
+  role     = "member"
+}
+
+# Example: Persons whose name starts with a prefix
+data "kanidm_persons" "contractors" {
+  name_prefix = "ext-"
+}
+
+# Example: Raw Kanidm filter - persons with an email address
+data "kanidm_persons" "with_mail" {
+  filter = jsonencode({ pres = "mail" })
+}
@@ -0,0 +1,20 @@
+# Example: Derive a VLAN from the groups in an account's RADIUS token
+data "kanidm_radius_token" "alice" {
+  id = kanidm_radius_credential.alice.id
+}
+
+locals {
+  vlan_by_group = {
+    "staff@idm.example.com"  = 10
+    "guests@idm.example.com" = 30
+  }
+
+  alice_vlan = try(
+    [for g in data.kanidm_radius_token.alice.groups : local.vlan_by_group[g.spn] if contains(keys(local.vlan_by_group), g.spn)][0],
+    20
+  )
+}
+
+output "alice_vlan" {
+  value = local.alice_vlan
+}
@@ -0,0 +1,13 @@
+# Example: List every recycled entry
+data "kanidm_recycle_bin" "all" {}
+
+output "recycled_names" {
+  description = "Names of all entries in the recycle bin"
+  value       = data.kanidm_recycle_bin.all.names
+}
+
+# Example: Recycled person accounts of contractors
+data "kanidm_recycle_bin" "contractors" {
+  kind        = "person"
+  name_prefix = "ext-"
+}
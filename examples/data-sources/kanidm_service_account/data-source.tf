@@ -0,0 +1,9 @@
+# Example: Check whether a service account exists
+data "kanidm_service_account" "ci" {
+  id = "ci"
+}
+
+output "ci_exists" {
+  description = "Whether the CI service account exists"
+  value       = data.kanidm_service_account.ci.exists
+}
@@ -0,0 +1,16 @@
+# Example: Read an OAuth2 client secret without storing it in state
+ephemeral "kanidm_oauth2_basic_secret" "grafana" {
+  name = kanidm_oauth2_basic.grafana.name
+}
+
+# Example: Hand the secret to Vault through a write-only argument
+resource "vault_kv_secret_v2" "grafana_oidc" {
+  mount = "secret"
+  name  = "grafana/oidc"
+
+  data_json_wo = jsonencode({
+    client_id     = kanidm_oauth2_basic.grafana.name
+    client_secret = ephemeral.kanidm_oauth2_basic_secret.grafana.client_secret
+  })
+  data_json_wo_version = 1
+}
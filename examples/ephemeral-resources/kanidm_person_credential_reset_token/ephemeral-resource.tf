@@ -0,0 +1,18 @@
+# Example: Issue a credential reset token without storing it in state
+# A new token is issued every time Terraform opens the ephemeral resource
+ephemeral "kanidm_person_credential_reset_token" "alice" {
+  id  = kanidm_person.alice.id
+  ttl = 86400 # 24 hours
+}
+
+# Example: Hand the token to a secret manager through a write-only argument
+resource "vault_kv_secret_v2" "alice_reset" {
+  mount = "secret"
+  name  = "kanidm/alice-reset"
+
+  data_json_wo = jsonencode({
+    token       = ephemeral.kanidm_person_credential_reset_token.alice.token
+    expiry_time = ephemeral.kanidm_person_credential_reset_token.alice.expiry_time
+  })
+  data_json_wo_version = 1
+}
@@ -0,0 +1,25 @@
+# Example: Mint a short-lived API token without storing it in state
+# A new token is minted every time Terraform opens the ephemeral resource,
+# and revoked when Terraform is done with it
+ephemeral "kanidm_service_account_api_token" "ci" {
+  id         = kanidm_service_account.ci.id
+  label      = "ci-pipeline"
+  ttl        = 86400 # 24 hours
+  read_write = false
+
+  # The token is stored outside Terraform, so leave it to expire instead
+  revoke_on_close = false
+}
+
+# Example: Hand the token to Kubernetes through a write-only argument
+resource "kubernetes_secret_v1" "ci" {
+  metadata {
+    name      = "kanidm-ci"
+    namespace = "ci"
+  }
+
+  data_wo = {
+    token = ephemeral.kanidm_service_account_api_token.ci.token
+  }
+  data_wo_revision = 1
+}
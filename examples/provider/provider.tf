@@ -39,3 +39,21 @@ provider "kanidm" {
   url   = "https://idm.s8i.ca"
   token = data.onepassword_item.kanidm_admin_token.credential
 }
+
+# Revive recycled entries when a deleted person, group or service account
+# is recreated, so it keeps its UUID and memberships
+provider "kanidm" {
+  alias         = "revive"
+  url           = "https://idm.s8i.ca"
+  token         = var.kanidm_token
+  deletion_mode = "recycle"
+}
+
+# Refuse to destroy or replace critical entries, whatever the
+# deletion_protection setting of the resources managing them
+provider "kanidm" {
+  alias           = "protected"
+  url             = "https://idm.s8i.ca"
+  token           = var.kanidm_token
+  protected_names = ["idm_admins", "breakglass"]
+}
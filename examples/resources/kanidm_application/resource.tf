@@ -0,0 +1,11 @@
+# Example: LDAP application for mail clients
+resource "kanidm_group" "mail_users" {
+  id          = "mail-users"
+  description = "People allowed to use mail"
+}
+
+resource "kanidm_application" "mail" {
+  id           = "mail"
+  displayname  = "Mail"
+  linked_group = kanidm_group.mail_users.id
+}
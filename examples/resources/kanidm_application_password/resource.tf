@@ -0,0 +1,30 @@
+# Example: Provision an LDAP-bound service end to end
+resource "kanidm_person" "nextcloud" {
+  id          = "svc-nextcloud"
+  displayname = "Nextcloud"
+}
+
+resource "kanidm_group" "nextcloud_ldap" {
+  id      = "nextcloud-ldap"
+  members = [kanidm_person.nextcloud.id]
+}
+
+resource "kanidm_application" "nextcloud" {
+  id           = "nextcloud"
+  displayname  = "Nextcloud"
+  linked_group = kanidm_group.nextcloud_ldap.id
+}
+
+resource "kanidm_application_password" "nextcloud" {
+  person      = kanidm_person.nextcloud.id
+  application = kanidm_application.nextcloud.id
+  label       = "nextcloud-ldap-bind"
+
+  # The person must be in the linked group before a password can be issued
+  depends_on = [kanidm_group.nextcloud_ldap]
+}
+
+output "nextcloud_ldap_password" {
+  value     = kanidm_application_password.nextcloud.secret
+  sensitive = true
+}
@@ -0,0 +1,14 @@
+# Example: Manage the domain-wide settings of a Kanidm instance
+# Only the settings given here are managed; the rest keep their current values
+resource "kanidm_domain" "this" {
+  display_name                  = "Example Corp"
+  ldap_basedn                   = "dc=example,dc=com"
+  ldap_allow_unix_password_bind = false
+
+  # Uploaded again whenever the file content changes
+  image = "${path.module}/logo.png"
+}
+
+# Example: Imported domain settings
+# Import command: terraform import kanidm_domain.this domain
+# All current settings are managed after import
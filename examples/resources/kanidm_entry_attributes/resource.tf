@@ -0,0 +1,31 @@
+# Example: Set an attribute the provider does not model yet
+resource "kanidm_entry_attributes" "alice_legalname" {
+  kind     = "person"
+  entry_id = kanidm_person.alice.id
+
+  attributes = {
+    legalname = ["Alice Margaret Smith"]
+  }
+}
+
+# Example: Give a group a POSIX gidnumber
+resource "kanidm_entry_attributes" "developers_posix" {
+  kind     = "group"
+  entry_id = kanidm_group.developers.id
+
+  attributes = {
+    gidnumber = ["20001"]
+  }
+}
+
+# Example: Imported existing entry
+# Import command: terraform import kanidm_entry_attributes.existing person/existing/legalname
+# Listing attributes after the entry reads their current values into state
+resource "kanidm_entry_attributes" "existing" {
+  kind     = "person"
+  entry_id = "existing"
+
+  attributes = {
+    legalname = ["Existing User"]
+  }
+}
@@ -11,9 +11,11 @@ resource "kanidm_group" "developers" {
 }
 
 # Example: Group with only person members
+# deletion_protection refuses to destroy the group until it is set to false
 resource "kanidm_group" "admins" {
-  id          = "infrastructure-admins"
-  description = "Infrastructure administrators with full access"
+  id                  = "infrastructure-admins"
+  description         = "Infrastructure administrators with full access"
+  deletion_protection = true
 
   members = [
     kanidm_person.alice_password.id,
@@ -39,6 +41,7 @@ resource "kanidm_group" "all_staff" {
 
 # Example: Imported existing group
 # Import command: terraform import kanidm_group.existing_group group_name
+# The UUID of the group is also accepted as the import ID
 resource "kanidm_group" "existing_group" {
   id          = "existing"
   description = "Existing Group"
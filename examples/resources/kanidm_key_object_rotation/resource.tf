@@ -0,0 +1,33 @@
+# Example: Rotate the OIDC signing key of an OAuth2 client
+# The new key becomes active a day after apply so relying parties can
+# fetch it first. Change the trigger to rotate again.
+resource "kanidm_key_object_rotation" "grafana" {
+  oauth2_client = kanidm_oauth2_basic.grafana.name
+  rotate_at     = timeadd(plantimestamp(), "24h")
+
+  triggers = {
+    drill = "2026-q4"
+  }
+
+  lifecycle {
+    ignore_changes = [rotate_at]
+  }
+}
+
+# Example: Revoke a compromised key after rotating
+resource "kanidm_key_object_rotation" "grafana_compromise" {
+  oauth2_client  = kanidm_oauth2_basic.grafana.name
+  revoke_key_ids = ["<compromised key id>"]
+}
+
+# Publish the key set so relying parties can be pre-warmed
+output "grafana_jwks" {
+  value = kanidm_key_object_rotation.grafana.jwks
+}
+
+# Example: Rotate the domain signing keys
+resource "kanidm_key_object_rotation" "domain" {
+  triggers = {
+    drill = "2026-q4"
+  }
+}
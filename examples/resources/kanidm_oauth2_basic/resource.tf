@@ -17,6 +17,25 @@ resource "kanidm_oauth2_basic" "grafana" {
     group  = "developers"
     scopes = ["openid", "profile", "email"]
   }
+
+  # Extra scopes for admins, on top of their scope_map
+  sup_scope_map {
+    group  = "admins"
+    scopes = ["grafana_admin"]
+  }
+
+  # Custom claim used by Grafana's role_attribute_path
+  claim_map {
+    claim  = "grafana_role"
+    group  = "admins"
+    values = ["Admin"]
+  }
+
+  claim_map {
+    claim  = "grafana_role"
+    group  = "developers"
+    values = ["Editor"]
+  }
 }
 
 # Store the client secret securely
@@ -74,8 +93,27 @@ resource "kanidm_oauth2_basic" "simple_app" {
   ]
 }
 
+# Example: Legacy application that cannot use PKCE or ES256
+resource "kanidm_oauth2_basic" "legacy_app" {
+  name        = "legacy-app"
+  displayname = "Legacy Application"
+  origin      = "https://legacy.example.com"
+
+  redirect_uris = [
+    "https://legacy.example.com/oauth/callback"
+  ]
+
+  allow_insecure_client_disable_pkce = true
+  legacy_crypto                      = true
+  prefer_short_username              = true
+  strict_redirect_uri                = true
+}
+
 # Example: Imported existing OAuth2 client
 # Import command: terraform import kanidm_oauth2_basic.existing client_name
+# The UUID of the client is also accepted as the import ID
+# Scope maps, claim maps, redirect URIs and settings are read from Kanidm, so
+# run `terraform plan` after import and copy any differences into the config
 # Note: Client secret will be automatically retrieved from Kanidm after import
 resource "kanidm_oauth2_basic" "existing" {
   name        = "existing-client"
@@ -1,14 +1,12 @@
 # Example: Person account with password-based authentication
+# password is write-only (Terraform 1.11+) and never stored in state.
+# Bump password_version to push a new password.
 resource "kanidm_person" "alice_password" {
-  id          = "alice"
-  displayname = "Alice Smith"
-  mail        = ["alice@example.com"]
-  password    = var.alice_password
-
-  lifecycle {
-    # Ignore password changes after creation (managed externally)
-    ignore_changes = [password]
-  }
+  id               = "alice"
+  displayname      = "Alice Smith"
+  mail             = ["alice@example.com"]
+  password         = var.alice_password
+  password_version = 1
 }
 
 # Example: Person account with passkey/modern authentication (recommended)
@@ -18,6 +16,9 @@ resource "kanidm_person" "bob_passkey" {
   mail                            = ["bob@example.com"]
   generate_credential_reset_token = true
   credential_reset_token_ttl      = 7200 # 2 hours
+
+  # Bump to issue a new token, e.g. after the previous one expired
+  credential_reset_token_version = 1
 }
 
 # Output the credential reset token for Bob
@@ -36,7 +37,19 @@ resource "kanidm_person" "charlie" {
 
 # Example: Imported existing person account
 # Import command: terraform import kanidm_person.existing_user username
+# The UUID of the account is also accepted as the import ID
 resource "kanidm_person" "existing_user" {
   id          = "existing"
   displayname = "Existing User"
 }
+
+# Example: Offboarded person account
+# locked sets the account expiry so the person can no longer authenticate.
+# With lock_on_destroy, removing this block locks the account instead of
+# deleting it, leaving the deletion to be done in Kanidm later.
+resource "kanidm_person" "dave_offboarded" {
+  id              = "dave"
+  displayname     = "Dave Jones"
+  locked          = true
+  lock_on_destroy = true
+}
@@ -0,0 +1,15 @@
+# Example: RADIUS secret for Wi-Fi access
+resource "kanidm_radius_credential" "alice" {
+  id = kanidm_person.alice.id
+}
+
+# Example: Regenerate the secret after a device is lost
+resource "kanidm_radius_credential" "bob" {
+  id             = kanidm_person.bob.id
+  secret_version = 2
+}
+
+output "alice_radius_secret" {
+  value     = kanidm_radius_credential.alice.secret
+  sensitive = true
+}
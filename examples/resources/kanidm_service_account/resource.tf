@@ -25,6 +25,7 @@ resource "kanidm_service_account" "prometheus" {
 
 # Example: Imported existing service account
 # Import command: terraform import kanidm_service_account.existing existing_account_id
+# The UUID of the account is also accepted as the import ID
 resource "kanidm_service_account" "existing" {
   id          = "existing-service"
   displayname = "Existing Service Account"
@@ -0,0 +1,28 @@
+# Example: Sync account for importing people from the HR system
+resource "kanidm_sync_account" "hr" {
+  id          = "hr-sync"
+  description = "Import from the HR system"
+
+  # Attributes users manage themselves in Kanidm
+  sync_yield_authority = ["legalname", "ssh_publickey"]
+
+  generate_token = true
+}
+
+# Store the sync token for the sync tool
+output "hr_sync_token" {
+  value     = kanidm_sync_account.hr.token
+  sensitive = true
+}
+
+# Example: Rotate the sync token by bumping its version
+resource "kanidm_sync_account" "ldap" {
+  id             = "ldap-sync"
+  generate_token = true
+  token_label    = "ldap-sync-tool"
+  token_version  = 2
+
+  # Delete the synchronised entries when the sync account is destroyed,
+  # rather than keeping them as regular Kanidm entries
+  destroy_action = "terminate"
+}
@@ -0,0 +1,14 @@
+# Example: Names that cannot be used for persons, groups or service accounts
+resource "kanidm_system_denied_names" "this" {
+  values = [
+    "root",
+    "administrator",
+    "postmaster",
+    "hostmaster",
+  ]
+}
+
+# Example: Denied names maintained as a file in the repository
+# resource "kanidm_system_denied_names" "this" {
+#   file = "${path.module}/denied_names.txt"
+# }
@@ -0,0 +1,11 @@
+# Example: Password badlist maintained as a file in the repository
+# One password per line; blank lines and lines starting with # are ignored.
+# Only a hash of the entries is kept in state, and only changed entries
+# are sent to Kanidm on apply.
+resource "kanidm_system_password_badlist" "this" {
+  file = "${path.module}/badlist.txt"
+}
+
+# Example: Imported password badlist
+# Import command: terraform import kanidm_system_password_badlist.this badlist_password
+# The current entries are adopted into values
@@ -0,0 +1,146 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MinServerVersion is the oldest Kanidm release the provider supports
+var MinServerVersion = Version{Major: 1, Minor: 8, Patch: 5}
+
+// Version is a Kanidm server release version
+type Version struct {
+	Major int
+	Minor int
+	Patch int
+}
+
+// ParseVersion parses versions such as "1.8.5", "v1.8.5" or "1.9.0-dev"
+func ParseVersion(s string) (Version, error) {
+	s = strings.TrimPrefix(strings.TrimSpace(s), "v")
+	if i := strings.IndexAny(s, "-+ "); i >= 0 {
+		s = s[:i]
+	}
+
+	parts := strings.Split(s, ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return Version{}, fmt.Errorf("invalid version %q", s)
+	}
+
+	nums := make([]int, 3)
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return Version{}, fmt.Errorf("invalid version %q: %w", s, err)
+		}
+		nums[i] = n
+	}
+
+	return Version{Major: nums[0], Minor: nums[1], Patch: nums[2]}, nil
+}
+
+// String returns the version in major.minor.patch form
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// AtLeast reports whether v is the same as or newer than other
+func (v Version) AtLeast(other Version) bool {
+	if v.Major != other.Major {
+		return v.Major > other.Major
+	}
+	if v.Minor != other.Minor {
+		return v.Minor > other.Minor
+	}
+	return v.Patch >= other.Patch
+}
+
+// Feature is a server capability introduced in a specific Kanidm release
+type Feature struct {
+	Name       string
+	MinVersion Version
+}
+
+var (
+	// FeatureOAuth2NameAttribute stores the OAuth2 client name in "name" instead of "oauth2_rs_name"
+	FeatureOAuth2NameAttribute = Feature{Name: "OAuth2 client name attribute", MinVersion: Version{1, 1, 0}}
+	// FeatureOAuth2ClaimMaps allows custom claims to be mapped from group membership
+	FeatureOAuth2ClaimMaps = Feature{Name: "OAuth2 claim maps", MinVersion: Version{1, 1, 0}}
+)
+
+// UnsupportedFeatureError is returned when the server is too old for a feature
+type UnsupportedFeatureError struct {
+	Feature       Feature
+	ServerVersion Version
+}
+
+// Error implements the error interface
+func (e *UnsupportedFeatureError) Error() string {
+	return fmt.Sprintf("%s requires Kanidm >= %s (server is %s)", e.Feature.Name, e.Feature.MinVersion, e.ServerVersion)
+}
+
+// Capabilities describes what the connected Kanidm server supports.
+// When the version is unknown every feature is assumed to be available,
+// so the server remains the final authority.
+type Capabilities struct {
+	Version Version
+	Known   bool
+}
+
+// Supports reports whether the server provides the feature
+func (caps *Capabilities) Supports(f Feature) bool {
+	if !caps.Known {
+		return true
+	}
+	return caps.Version.AtLeast(f.MinVersion)
+}
+
+// Require returns an *UnsupportedFeatureError if the server lacks the feature
+func (caps *Capabilities) Require(f Feature) error {
+	if caps.Supports(f) {
+		return nil
+	}
+	return &UnsupportedFeatureError{Feature: f, ServerVersion: caps.Version}
+}
+
+// OAuth2NameAttr returns the attribute holding an OAuth2 client's name
+func (caps *Capabilities) OAuth2NameAttr() string {
+	if caps.Supports(FeatureOAuth2NameAttribute) {
+		return "name"
+	}
+	return "oauth2_rs_name"
+}
+
+// Capabilities returns the capabilities of the connected server. The version
+// is taken from the provider's status check, or queried once on first use.
+func (c *Client) Capabilities(ctx context.Context) *Capabilities {
+	c.mu.Lock()
+	probe := c.serverVersion == "" && !c.versionProbed
+	c.versionProbed = true
+	c.mu.Unlock()
+
+	if probe {
+		// A failed probe leaves the version unknown, which is treated permissively
+		_, _ = c.GetStatus(ctx)
+	}
+
+	version, err := ParseVersion(c.ServerVersion())
+	if err != nil {
+		return &Capabilities{}
+	}
+
+	return &Capabilities{Version: version, Known: true}
+}
+
+// Supports reports whether the connected server provides the feature
+func (c *Client) Supports(ctx context.Context, f Feature) bool {
+	return c.Capabilities(ctx).Supports(f)
+}
+
+// Require returns an *UnsupportedFeatureError if the connected server lacks
+// the feature
+func (c *Client) Require(ctx context.Context, f Feature) error {
+	return c.Capabilities(ctx).Require(f)
+}
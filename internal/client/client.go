@@ -4,28 +4,28 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
 	"strings"
+	"sync"
 	"time"
 )
 
-var (
-	// ErrNotFound indicates the requested resource was not found
-	ErrNotFound = errors.New("resource not found")
-	// ErrUnauthorized indicates authentication failed
-	ErrUnauthorized = errors.New("unauthorized")
-	// ErrForbidden indicates insufficient permissions
-	ErrForbidden = errors.New("forbidden")
-)
-
 // Client provides methods to interact with the Kanidm API
 type Client struct {
 	baseURL    string
 	token      string
 	httpClient *http.Client
+
+	deletionMode   DeletionMode
+	protectedNames map[string]bool
+
+	mu            sync.RWMutex
+	serverVersion string
+	versionProbed bool
 }
 
 // ClientOption configures the Client
@@ -62,6 +62,23 @@ func NewClient(baseURL, token string, opts ...ClientOption) *Client {
 	return c
 }
 
+// ServerVersion returns the Kanidm server version reported by the last
+// GetStatus call, or an empty string if it is not known
+func (c *Client) ServerVersion() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.serverVersion
+}
+
+// setServerVersion records the Kanidm server version
+func (c *Client) setServerVersion(version string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.serverVersion = version
+}
+
 // doRequest executes an HTTP request with proper error handling
 func (c *Client) doRequest(ctx context.Context, method, path string, body any) (*http.Response, error) {
 	var reqBody io.Reader
@@ -95,7 +112,50 @@ func (c *Client) doRequest(ctx context.Context, method, path string, body any) (
 	return resp, nil
 }
 
-// checkResponse validates HTTP response and returns typed errors
+// doMultipartRequest uploads a file as a single-part multipart form, as
+// used by the image endpoints
+func (c *Client) doMultipartRequest(ctx context.Context, method, path, field, filename, contentType string, data []byte) (*http.Response, error) {
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name=%q; filename=%q`, field, filename))
+	header.Set("Content-Type", contentType)
+
+	part, err := form.CreatePart(header)
+	if err != nil {
+		return nil, fmt.Errorf("create form part: %w", err)
+	}
+	if _, err := part.Write(data); err != nil {
+		return nil, fmt.Errorf("write form part: %w", err)
+	}
+	if err := form.Close(); err != nil {
+		return nil, fmt.Errorf("close form: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, &body)
+	if err != nil {
+		return nil, fmt.Errorf("create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("execute request: %w", err)
+	}
+
+	if err := c.checkResponse(resp); err != nil {
+		_ = resp.Body.Close()
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// checkResponse validates HTTP response and returns an *APIError on failure
 func (c *Client) checkResponse(resp *http.Response) error {
 	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
 		return nil
@@ -104,19 +164,7 @@ func (c *Client) checkResponse(resp *http.Response) error {
 	defer func() { _ = resp.Body.Close() }()
 	body, _ := io.ReadAll(resp.Body)
 
-	switch resp.StatusCode {
-	case http.StatusNotFound:
-		return ErrNotFound
-	case http.StatusUnauthorized:
-		return ErrUnauthorized
-	case http.StatusForbidden:
-		return ErrForbidden
-	default:
-		if len(body) > 0 {
-			return fmt.Errorf("API error (HTTP %d): %s", resp.StatusCode, body)
-		}
-		return fmt.Errorf("API error (HTTP %d)", resp.StatusCode)
-	}
+	return newAPIError(resp, body)
 }
 
 // decodeResponse unmarshals the response body into the target
@@ -0,0 +1,132 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// credentialUpdateIntentTTL is the lifetime in seconds of the intent tokens
+// minted to start a credential update session. They are exchanged at once,
+// so the shortest lifetime Kanidm accepts is enough.
+const credentialUpdateIntentTTL = 300
+
+// CredentialUpdateSession is an open Kanidm credential update session
+type CredentialUpdateSession struct {
+	Token string `json:"token"`
+}
+
+// CredentialUpdateStatus is the state of a credential update session, as
+// returned after each change
+type CredentialUpdateStatus struct {
+	SPN       string `json:"spn"`
+	CanCommit bool   `json:"can_commit"`
+	// Warnings explain why the session cannot be committed, e.g. "MfaRequired"
+	// when the account policy needs more than a password
+	Warnings []string `json:"warnings"`
+}
+
+// ErrCredentialUpdateIncomplete indicates a credential update session was
+// left in a state Kanidm will not commit
+var ErrCredentialUpdateIncomplete = errors.New("credential update cannot be committed")
+
+// BeginCredentialUpdate mints an intent token for the person and exchanges it
+// for a credential update session
+func (c *Client) BeginCredentialUpdate(ctx context.Context, id string) (*CredentialUpdateSession, *CredentialUpdateStatus, error) {
+	ttl := credentialUpdateIntentTTL
+	intent, err := c.CreatePersonCredentialResetToken(ctx, id, &ttl)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := c.doRequest(ctx, "POST", "/v1/credential/_exchange_intent", intent.Token)
+	if err != nil {
+		return nil, nil, fmt.Errorf("exchange credential update intent: %w", err)
+	}
+
+	// The session token and status are returned as a two element tuple
+	var result []json.RawMessage
+	if err := decodeResponse(resp, &result); err != nil {
+		return nil, nil, err
+	}
+	if len(result) != 2 {
+		return nil, nil, fmt.Errorf("exchange credential update intent: unexpected response with %d elements", len(result))
+	}
+
+	var session CredentialUpdateSession
+	if err := json.Unmarshal(result[0], &session); err != nil {
+		return nil, nil, fmt.Errorf("decode credential update session: %w", err)
+	}
+
+	var status CredentialUpdateStatus
+	if err := json.Unmarshal(result[1], &status); err != nil {
+		return nil, nil, fmt.Errorf("decode credential update status: %w", err)
+	}
+
+	return &session, &status, nil
+}
+
+// UpdateCredential submits a change to an open credential update session.
+// Kanidm rejects weak or badlisted passwords with a passwordquality error.
+func (c *Client) UpdateCredential(ctx context.Context, session *CredentialUpdateSession, request map[string]any) (*CredentialUpdateStatus, error) {
+	resp, err := c.doRequest(ctx, "POST", "/v1/credential/_update", []any{request, session})
+	if err != nil {
+		return nil, fmt.Errorf("update credential: %w", err)
+	}
+
+	var status CredentialUpdateStatus
+	if err := decodeResponse(resp, &status); err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
+
+// CommitCredentialUpdate applies the changes made in a credential update session
+func (c *Client) CommitCredentialUpdate(ctx context.Context, session *CredentialUpdateSession) error {
+	resp, err := c.doRequest(ctx, "POST", "/v1/credential/_commit", session)
+	if err != nil {
+		return fmt.Errorf("commit credential update: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return nil
+}
+
+// CancelCredentialUpdate discards a credential update session
+func (c *Client) CancelCredentialUpdate(ctx context.Context, session *CredentialUpdateSession) error {
+	resp, err := c.doRequest(ctx, "POST", "/v1/credential/_cancel", session)
+	if err != nil {
+		return fmt.Errorf("cancel credential update: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return nil
+}
+
+// SetPersonPassword sets the primary password of a person account through a
+// credential update session: begin, submit the password, check the session
+// can be committed, then commit. The session is cancelled on failure.
+func (c *Client) SetPersonPassword(ctx context.Context, id, password string) error {
+	session, _, err := c.BeginCredentialUpdate(ctx, id)
+	if err != nil {
+		return fmt.Errorf("set person password: %w", err)
+	}
+
+	status, err := c.UpdateCredential(ctx, session, map[string]any{"password": password})
+	if err == nil && !status.CanCommit {
+		err = fmt.Errorf("%w: %s", ErrCredentialUpdateIncomplete, strings.Join(status.Warnings, ", "))
+	}
+	if err != nil {
+		_ = c.CancelCredentialUpdate(ctx, session)
+		return fmt.Errorf("set person password: %w", err)
+	}
+
+	if err := c.CommitCredentialUpdate(ctx, session); err != nil {
+		return fmt.Errorf("set person password: %w", err)
+	}
+
+	return nil
+}
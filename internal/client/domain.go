@@ -0,0 +1,121 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"path/filepath"
+	"time"
+)
+
+// Domain attribute names
+const (
+	DomainAttrDisplayName            = "domain_display_name"
+	DomainAttrLDAPBaseDN             = "domain_ldap_basedn"
+	DomainAttrLDAPAllowUnixPasswords = "ldap_allow_unix_pw_bind"
+)
+
+// Domain represents the Kanidm domain configuration entry
+type Domain struct {
+	UUID                      string
+	Name                      string
+	DisplayName               string
+	LDAPBaseDN                string
+	LDAPAllowUnixPasswordBind bool
+}
+
+// GetDomain retrieves the domain configuration entry
+func (c *Client) GetDomain(ctx context.Context) (*Domain, error) {
+	resp, err := c.doRequest(ctx, "GET", "/v1/domain", nil)
+	if err != nil {
+		return nil, fmt.Errorf("get domain: %w", err)
+	}
+
+	// The domain is returned as a single element list
+	var entries []Entry
+	if err := decodeResponse(resp, &entries); err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("get domain: %w", ErrNotFound)
+	}
+
+	entry := &entries[0]
+	return &Domain{
+		UUID:                      entry.GetString("uuid"),
+		Name:                      entry.GetString("domain_name"),
+		DisplayName:               entry.GetString(DomainAttrDisplayName),
+		LDAPBaseDN:                entry.GetString(DomainAttrLDAPBaseDN),
+		LDAPAllowUnixPasswordBind: entryBool(entry, DomainAttrLDAPAllowUnixPasswords),
+	}, nil
+}
+
+// SetDomainAttribute replaces all values of a domain attribute
+func (c *Client) SetDomainAttribute(ctx context.Context, attr string, values []string) error {
+	resp, err := c.doRequest(ctx, "PUT", "/v1/domain/_attr/"+attr, values)
+	if err != nil {
+		return fmt.Errorf("set domain attribute %s: %w", attr, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return nil
+}
+
+// PurgeDomainAttribute removes a domain attribute, restoring its default
+func (c *Client) PurgeDomainAttribute(ctx context.Context, attr string) error {
+	resp, err := c.doRequest(ctx, "DELETE", "/v1/domain/_attr/"+attr, nil)
+	if err != nil {
+		return fmt.Errorf("purge domain attribute %s: %w", attr, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return nil
+}
+
+// SetDomainImage uploads the image shown on the login page. The content
+// type is derived from the file name; Kanidm accepts PNG, JPEG, GIF, SVG
+// and WebP images.
+func (c *Client) SetDomainImage(ctx context.Context, filename string, data []byte) error {
+	contentType := mime.TypeByExtension(filepath.Ext(filename))
+	if contentType == "" {
+		return fmt.Errorf("set domain image: unknown image type for %q", filename)
+	}
+
+	resp, err := c.doMultipartRequest(ctx, "POST", "/v1/domain/_image", "image", filepath.Base(filename), contentType, data)
+	if err != nil {
+		return fmt.Errorf("set domain image: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return nil
+}
+
+// DeleteDomainImage removes the login page image
+func (c *Client) DeleteDomainImage(ctx context.Context) error {
+	resp, err := c.doRequest(ctx, "DELETE", "/v1/domain/_image", nil)
+	if err != nil {
+		return fmt.Errorf("delete domain image: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return nil
+}
+
+// RotateDomainKeys schedules new domain signing keys to become active at the
+// given time. Existing keys remain valid for verification.
+func (c *Client) RotateDomainKeys(ctx context.Context, at time.Time) error {
+	if err := c.SetDomainAttribute(ctx, keyActionRotate, []string{at.UTC().Format(time.RFC3339)}); err != nil {
+		return fmt.Errorf("rotate domain keys: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeDomainKey revokes a domain signing key
+func (c *Client) RevokeDomainKey(ctx context.Context, keyID string) error {
+	if err := c.SetDomainAttribute(ctx, keyActionRevoke, []string{keyID}); err != nil {
+		return fmt.Errorf("revoke domain key: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,138 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// EntryKind identifies the API collection an entry belongs to
+type EntryKind string
+
+const (
+	EntryKindPerson         EntryKind = "person"
+	EntryKindGroup          EntryKind = "group"
+	EntryKindServiceAccount EntryKind = "service_account"
+	EntryKindOAuth2         EntryKind = "oauth2"
+)
+
+// EntryKinds lists every supported entry kind
+var EntryKinds = []EntryKind{
+	EntryKindPerson,
+	EntryKindGroup,
+	EntryKindServiceAccount,
+	EntryKindOAuth2,
+}
+
+// ParseEntryKind validates and converts a string to an EntryKind
+func ParseEntryKind(s string) (EntryKind, error) {
+	for _, kind := range EntryKinds {
+		if string(kind) == s {
+			return kind, nil
+		}
+	}
+	return "", fmt.Errorf("unknown entry kind %q", s)
+}
+
+// entryPath returns the API path of an entry
+func entryPath(kind EntryKind, id string) string {
+	return fmt.Sprintf("/v1/%s/%s", kind, id)
+}
+
+// GetEntry retrieves the raw attributes of an entry
+func (c *Client) GetEntry(ctx context.Context, kind EntryKind, id string) (*Entry, error) {
+	resp, err := c.doRequest(ctx, "GET", entryPath(kind, id), nil)
+	if err != nil {
+		return nil, fmt.Errorf("get %s: %w", kind, err)
+	}
+
+	// Kanidm answers with null rather than 404 when no entry matches
+	var entry *Entry
+	if err := decodeResponse(resp, &entry); err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, fmt.Errorf("get %s %s: %w", kind, id, ErrNotFound)
+	}
+
+	return entry, nil
+}
+
+// SetEntryAttribute replaces all values of an attribute on an entry
+func (c *Client) SetEntryAttribute(ctx context.Context, kind EntryKind, id, attr string, values []string) error {
+	// OAuth2 clients have no attribute endpoint, so they are modified with PATCH
+	if kind == EntryKindOAuth2 {
+		return c.patchEntry(ctx, kind, id, map[string]any{attr: values})
+	}
+
+	resp, err := c.doRequest(ctx, "PUT", fmt.Sprintf("%s/_attr/%s", entryPath(kind, id), attr), values)
+	if err != nil {
+		return fmt.Errorf("set %s attribute %s: %w", kind, attr, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return nil
+}
+
+// PurgeEntryAttribute removes all values of an attribute from an entry
+func (c *Client) PurgeEntryAttribute(ctx context.Context, kind EntryKind, id, attr string) error {
+	if kind == EntryKindOAuth2 {
+		return c.patchEntry(ctx, kind, id, map[string]any{attr: []string{}})
+	}
+
+	resp, err := c.doRequest(ctx, "DELETE", fmt.Sprintf("%s/_attr/%s", entryPath(kind, id), attr), nil)
+	if err != nil {
+		return fmt.Errorf("purge %s attribute %s: %w", kind, attr, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return nil
+}
+
+// patchEntry applies an attribute update to an entry
+func (c *Client) patchEntry(ctx context.Context, kind EntryKind, id string, attrs map[string]any) error {
+	resp, err := c.doRequest(ctx, "PATCH", entryPath(kind, id), NewUpdateRequest(attrs))
+	if err != nil {
+		return fmt.Errorf("update %s: %w", kind, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return nil
+}
+
+// RenameEntry changes the name of an entry. The entry keeps its UUID, so
+// credentials, memberships and references to it are preserved.
+func (c *Client) RenameEntry(ctx context.Context, kind EntryKind, id, newName string) error {
+	attr := "name"
+	if kind == EntryKindOAuth2 {
+		attr = c.Capabilities(ctx).OAuth2NameAttr()
+	}
+
+	if err := c.patchEntry(ctx, kind, id, map[string]any{attr: []string{newName}}); err != nil {
+		return fmt.Errorf("rename %s: %w", kind, err)
+	}
+
+	return nil
+}
+
+// IsUUID reports whether s is formatted as a UUID rather than a name
+func IsUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+
+	for i, r := range s {
+		switch i {
+		case 8, 13, 18, 23:
+			if r != '-' {
+				return false
+			}
+		default:
+			if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,190 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+var (
+	// ErrNotFound indicates the requested resource was not found
+	ErrNotFound = errors.New("resource not found")
+	// ErrUnauthorized indicates authentication failed
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrForbidden indicates insufficient permissions
+	ErrForbidden = errors.New("forbidden")
+)
+
+// Kanidm OperationError codes, as serialized by the server (lowercase)
+const (
+	ErrCodeAccessDenied            = "accessdenied"
+	ErrCodeAttributeUniqueness     = "attributeuniqueness"
+	ErrCodeInvalidAttribute        = "invalidattribute"
+	ErrCodeInvalidAttributeName    = "invalidattributename"
+	ErrCodeMissingAttribute        = "missingattribute"
+	ErrCodeNoMatchingEntries       = "nomatchingentries"
+	ErrCodeNotAuthenticated        = "notauthenticated"
+	ErrCodeNotAuthorised           = "notauthorised"
+	ErrCodePasswordQuality         = "passwordquality"
+	ErrCodeReferenceLoop           = "referenceloop"
+	ErrCodeResourceLimit           = "resourcelimit"
+	ErrCodeSchemaViolation         = "schemaviolation"
+	ErrCodeSessionExpired          = "sessionexpired"
+	ErrCodeSystemProtectedObject   = "systemprotectedobject"
+	ErrCodeSystemProtectedAttr     = "systemprotectedattribute"
+	ErrCodeUniqueConstraintViolate = "uniqueconstraintviolation"
+	ErrCodeValueDenyName           = "valuedenyname"
+)
+
+// requestIDHeader is the response header Kanidm uses to report the operation ID
+const requestIDHeader = "X-KANIDM-OPID"
+
+// APIError describes a non-successful response from the Kanidm API.
+// It satisfies errors.Is for ErrNotFound, ErrUnauthorized and ErrForbidden
+// so existing status checks keep working.
+type APIError struct {
+	StatusCode int
+	// Code is the Kanidm OperationError variant, e.g. "passwordquality"
+	Code string
+	// Details holds the values attached to the error variant, such as the
+	// attributes that violated uniqueness or the password quality feedback
+	Details   []string
+	Method    string
+	Path      string
+	RequestID string
+	Body      string
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "API error (HTTP %d", e.StatusCode)
+	if e.Method != "" && e.Path != "" {
+		fmt.Fprintf(&b, " %s %s", e.Method, e.Path)
+	}
+	b.WriteString(")")
+
+	switch {
+	case e.Code != "" && len(e.Details) > 0:
+		fmt.Fprintf(&b, ": %s [%s]", e.Code, strings.Join(e.Details, ", "))
+	case e.Code != "":
+		fmt.Fprintf(&b, ": %s", e.Code)
+	case e.Body != "":
+		fmt.Fprintf(&b, ": %s", e.Body)
+	}
+
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, " (request id: %s)", e.RequestID)
+	}
+
+	return b.String()
+}
+
+// Is maps HTTP status codes onto the package sentinel errors
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	}
+	return false
+}
+
+// HasCode reports whether the error carries the given Kanidm error code.
+// The comparison is case-insensitive so both "PasswordQuality" and
+// "passwordquality" match.
+func (e *APIError) HasCode(code string) bool {
+	return e.Code != "" && strings.EqualFold(e.Code, code)
+}
+
+// HasErrorCode reports whether err wraps an *APIError with the given code
+func HasErrorCode(err error, code string) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.HasCode(code)
+	}
+	return false
+}
+
+// newAPIError builds an APIError from a failed response and its body
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get(requestIDHeader),
+		Body:       strings.TrimSpace(string(body)),
+	}
+
+	if resp.Request != nil {
+		apiErr.Method = resp.Request.Method
+		if resp.Request.URL != nil {
+			apiErr.Path = resp.Request.URL.Path
+		}
+	}
+
+	var decoded any
+	if err := json.Unmarshal(body, &decoded); err == nil {
+		apiErr.Code, apiErr.Details = parseOperationError(decoded)
+	}
+
+	return apiErr
+}
+
+// parseOperationError extracts the variant name and its values from a
+// serialized Kanidm error. Unit variants are plain strings ("accessdenied"),
+// data-carrying variants are single-key objects ({"attributeuniqueness":
+// ["name","spn"]}), and WebError wraps them in {"OperationError": ...}.
+func parseOperationError(v any) (string, []string) {
+	switch val := v.(type) {
+	case string:
+		return val, nil
+	case map[string]any:
+		if len(val) != 1 {
+			return "", nil
+		}
+		for key, inner := range val {
+			if key == "OperationError" {
+				return parseOperationError(inner)
+			}
+			return key, flattenErrorDetails(inner)
+		}
+	}
+	return "", nil
+}
+
+// flattenErrorDetails renders nested error payloads into a flat string list
+func flattenErrorDetails(v any) []string {
+	switch val := v.(type) {
+	case nil:
+		return nil
+	case string:
+		return []string{val}
+	case []any:
+		var out []string
+		for _, item := range val {
+			out = append(out, flattenErrorDetails(item)...)
+		}
+		return out
+	case map[string]any:
+		keys := make([]string, 0, len(val))
+		for key := range val {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		var out []string
+		for _, key := range keys {
+			out = append(out, key)
+			out = append(out, flattenErrorDetails(val[key])...)
+		}
+		return out
+	default:
+		return []string{fmt.Sprint(val)}
+	}
+}
@@ -8,8 +8,11 @@ import (
 // Group represents a Kanidm group
 type Group struct {
 	ID          string
+	UUID        string
+	SPN         string
 	Description string
 	Members     []string
+	GIDNumber   string // Only set for POSIX groups
 }
 
 // CreateGroup creates a new group
@@ -43,11 +46,40 @@ func (c *Client) GetGroup(ctx context.Context, id string) (*Group, error) {
 		return nil, fmt.Errorf("get group: %w", err)
 	}
 
-	var entry Entry
+	// Kanidm answers with null rather than 404 when no group matches
+	var entry *Entry
 	if err := decodeResponse(resp, &entry); err != nil {
 		return nil, err
 	}
+	if entry == nil {
+		return nil, fmt.Errorf("get group %s: %w", id, ErrNotFound)
+	}
+
+	return groupFromEntry(entry), nil
+}
+
+// ListGroups retrieves all groups visible to the client
+func (c *Client) ListGroups(ctx context.Context) ([]*Group, error) {
+	entries, err := c.ListEntries(ctx, EntryKindGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	return groupsFromEntries(entries), nil
+}
+
+// SearchGroups retrieves the groups matching filter
+func (c *Client) SearchGroups(ctx context.Context, filter Filter) ([]*Group, error) {
+	entries, err := c.SearchEntries(ctx, FilterAnd(FilterEq("class", "group"), filter))
+	if err != nil {
+		return nil, err
+	}
+
+	return groupsFromEntries(entries), nil
+}
 
+// groupFromEntry converts a raw entry to a Group
+func groupFromEntry(entry *Entry) *Group {
 	// Ensure members is never nil
 	members := entry.GetStringSlice("member")
 	if members == nil {
@@ -56,9 +88,21 @@ func (c *Client) GetGroup(ctx context.Context, id string) (*Group, error) {
 
 	return &Group{
 		ID:          entry.GetString("name"),
+		UUID:        entry.GetString("uuid"),
+		SPN:         entry.GetString("spn"),
 		Description: entry.GetString("description"),
 		Members:     members,
-	}, nil
+		GIDNumber:   entry.GetString("gidnumber"),
+	}
+}
+
+// groupsFromEntries converts raw entries to Groups
+func groupsFromEntries(entries []Entry) []*Group {
+	groups := make([]*Group, 0, len(entries))
+	for i := range entries {
+		groups = append(groups, groupFromEntry(&entries[i]))
+	}
+	return groups
 }
 
 // UpdateGroup updates a group
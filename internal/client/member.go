@@ -0,0 +1,163 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// MemberRef identifies an entry that can be a group member. Persons,
+// service accounts and groups are all addressed the same way.
+type MemberRef struct {
+	UUID string
+	Name string
+	SPN  string
+	Kind EntryKind
+}
+
+// memberFilter builds the filter that matches id by UUID, SPN or name
+func memberFilter(id string) Filter {
+	switch {
+	case IsUUID(id):
+		return FilterEq("uuid", strings.ToLower(id))
+	case strings.Contains(id, "@"):
+		return FilterEq("spn", id)
+	default:
+		return FilterEq("name", id)
+	}
+}
+
+// ResolveMembers resolves member identifiers written as names, SPNs or UUIDs
+// to the entries they refer to. The result is keyed by the identifier as
+// given; identifiers that match no entry are left out.
+func (c *Client) ResolveMembers(ctx context.Context, ids []string) (map[string]*MemberRef, error) {
+	result := make(map[string]*MemberRef, len(ids))
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	filters := make([]Filter, 0, len(ids))
+	for _, id := range ids {
+		filters = append(filters, memberFilter(id))
+	}
+
+	entries, err := c.SearchEntries(ctx, FilterOr(filters...))
+	if err != nil {
+		return nil, fmt.Errorf("resolve members: %w", err)
+	}
+
+	refs := make([]*MemberRef, 0, len(entries))
+	for i := range entries {
+		refs = append(refs, &MemberRef{
+			UUID: strings.ToLower(entries[i].GetString("uuid")),
+			Name: entries[i].GetString("name"),
+			SPN:  entries[i].GetString("spn"),
+			Kind: entryKindFromClasses(entries[i].GetStringSlice("class")),
+		})
+	}
+
+	for _, id := range ids {
+		for _, ref := range refs {
+			if ref.matches(id) {
+				result[id] = ref
+				break
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// entryKindFromClasses derives the entry kind from its object classes
+func entryKindFromClasses(classes []string) EntryKind {
+	has := make(map[string]bool, len(classes))
+	for _, class := range classes {
+		has[strings.ToLower(class)] = true
+	}
+
+	switch {
+	case has["person"]:
+		return EntryKindPerson
+	case has["service_account"]:
+		return EntryKindServiceAccount
+	case has["oauth2_resource_server"]:
+		return EntryKindOAuth2
+	case has["group"]:
+		return EntryKindGroup
+	}
+	return ""
+}
+
+// matches reports whether id is the UUID, SPN or name of the entry
+func (m *MemberRef) matches(id string) bool {
+	return strings.EqualFold(id, m.UUID) || id == m.SPN || id == m.Name
+}
+
+// NormalizeMembers rewrites the members reported by Kanidm into the form used
+// in preferred whenever both refer to the same entry, so a configuration that
+// lists "alice" is not shown as drifting from "alice@idm.example.com".
+// Members with no counterpart in preferred are returned as Kanidm reported them.
+func (c *Client) NormalizeMembers(ctx context.Context, actual, preferred []string) ([]string, error) {
+	result := make([]string, 0, len(actual))
+	if len(actual) == 0 {
+		return result, nil
+	}
+
+	if len(preferred) == 0 {
+		return append(result, actual...), nil
+	}
+
+	forms, err := c.MemberForms(ctx, actual, preferred, false)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, id := range actual {
+		result = append(result, forms[id])
+	}
+
+	return result, nil
+}
+
+// MemberForms maps each identifier in actual to the identifier in preferred
+// that refers to the same entry. Identifiers with no counterpart map to the
+// entry name when useName is set, and to themselves otherwise.
+func (c *Client) MemberForms(ctx context.Context, actual, preferred []string, useName bool) (map[string]string, error) {
+	forms := make(map[string]string, len(actual))
+	if len(actual) == 0 {
+		return forms, nil
+	}
+
+	ids := make([]string, 0, len(actual)+len(preferred))
+	ids = append(ids, actual...)
+	ids = append(ids, preferred...)
+
+	refs, err := c.ResolveMembers(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	preferredByUUID := make(map[string]string, len(preferred))
+	for _, id := range preferred {
+		if ref, ok := refs[id]; ok {
+			preferredByUUID[ref.UUID] = id
+		}
+	}
+
+	for _, id := range actual {
+		forms[id] = id
+
+		ref, ok := refs[id]
+		if !ok {
+			continue
+		}
+
+		if form, ok := preferredByUUID[ref.UUID]; ok {
+			forms[id] = form
+		} else if useName && ref.Name != "" {
+			forms[id] = ref.Name
+		}
+	}
+
+	return forms, nil
+}
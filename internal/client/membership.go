@@ -0,0 +1,98 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// EffectiveMember is an entry that belongs to a group directly or through
+// nested groups
+type EffectiveMember struct {
+	MemberRef
+	// Path lists the group names from the queried group down to the group
+	// that holds the entry as a direct member
+	Path []string
+}
+
+// EffectiveMembership is the transitive closure of a group's members
+type EffectiveMembership struct {
+	Group *Group
+	// Members holds the persons and service accounts, each with the shortest
+	// path that grants the membership
+	Members []*EffectiveMember
+	// Groups holds the nested groups that were expanded
+	Groups []*EffectiveMember
+}
+
+// GetEffectiveMembers expands the members of a group, following nested
+// groups breadth first. Groups already visited are skipped, so membership
+// cycles terminate. Members the client is not allowed to read are omitted.
+func (c *Client) GetEffectiveMembers(ctx context.Context, groupID string) (*EffectiveMembership, error) {
+	root, err := c.GetGroup(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	type pending struct {
+		group *Group
+		path  []string
+	}
+
+	result := &EffectiveMembership{Group: root}
+	visited := map[string]bool{root.UUID: true}
+	queue := []pending{{group: root, path: []string{root.ID}}}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		refs, err := c.ResolveMembers(ctx, current.group.Members)
+		if err != nil {
+			return nil, fmt.Errorf("expand group %s: %w", current.group.ID, err)
+		}
+
+		for _, id := range current.group.Members {
+			ref, ok := refs[id]
+			if !ok || visited[ref.UUID] {
+				continue
+			}
+			visited[ref.UUID] = true
+
+			member := &EffectiveMember{MemberRef: *ref, Path: current.path}
+			if ref.Kind != EntryKindGroup {
+				result.Members = append(result.Members, member)
+				continue
+			}
+
+			result.Groups = append(result.Groups, member)
+
+			// A nested group deleted while expanding is skipped, so only a
+			// missing root group is reported as ErrNotFound
+			nested, err := c.GetGroup(ctx, ref.UUID)
+			if errors.Is(err, ErrNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, fmt.Errorf("expand group %s: %w", ref.Name, err)
+			}
+
+			path := make([]string, len(current.path), len(current.path)+1)
+			copy(path, current.path)
+			queue = append(queue, pending{group: nested, path: append(path, nested.ID)})
+		}
+	}
+
+	sortEffectiveMembers(result.Members)
+	sortEffectiveMembers(result.Groups)
+
+	return result, nil
+}
+
+// sortEffectiveMembers orders members by SPN so results are stable
+func sortEffectiveMembers(members []*EffectiveMember) {
+	sort.Slice(members, func(i, j int) bool {
+		return members[i].SPN < members[j].SPN
+	})
+}
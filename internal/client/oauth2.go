@@ -2,21 +2,77 @@ package client
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // OAuth2Client represents a Kanidm OAuth2 resource server
 type OAuth2Client struct {
-	Name         string
-	DisplayName  string
-	Origin       string
+	Name        string
+	UUID        string
+	DisplayName string
+	// Origin is the landing page users are sent to from the Kanidm app list
+	Origin string
+	// RedirectURIs are the URLs the client may redirect to after authorisation
 	RedirectURIs []string
+	// ScopeMaps and SupScopeMaps are keyed by group, as reported by Kanidm
 	ScopeMaps    map[string][]string
+	SupScopeMaps map[string][]string
+	ClaimMaps    []OAuth2ClaimMap
+	Flags        OAuth2Flags
 	ClientID     string // Computed
 	ClientSecret string // Only for basic/confidential clients, populated on creation
 	IsPublic     bool
 }
 
+// OAuth2ClaimMap maps members of a group to values of a custom claim
+type OAuth2ClaimMap struct {
+	Claim  string
+	Group  string
+	Join   string // One of the OAuth2ClaimJoin values
+	Values []string
+}
+
+// Claim map join strategies, controlling how multiple values are rendered
+const (
+	OAuth2ClaimJoinCSV   = "csv"
+	OAuth2ClaimJoinSSV   = "ssv"
+	OAuth2ClaimJoinArray = "array"
+)
+
+// oauth2ClaimJoinSeparators maps join strategies to the separator Kanidm
+// uses for them when displaying a claim map
+var oauth2ClaimJoinSeparators = map[string]string{
+	OAuth2ClaimJoinCSV:   ",",
+	OAuth2ClaimJoinSSV:   " ",
+	OAuth2ClaimJoinArray: ";",
+}
+
+// OAuth2Flags holds the boolean settings of an OAuth2 client
+type OAuth2Flags struct {
+	AllowInsecureClientDisablePKCE bool
+	LegacyCrypto                   bool
+	PreferShortUsername            bool
+	StrictRedirectURI              bool
+}
+
+// Attributes holding the OAuth2Flags settings
+const (
+	oauth2AttrDisablePKCE         = "oauth2_allow_insecure_client_disable_pkce"
+	oauth2AttrLegacyCrypto        = "oauth2_jwt_legacy_crypto_enable"
+	oauth2AttrPreferShortUsername = "oauth2_prefer_short_username"
+	oauth2AttrStrictRedirectURI   = "oauth2_strict_redirect_uri"
+)
+
+// ErrNoClientSecret indicates an OAuth2 client has no secret, as is the case
+// for public clients
+var ErrNoClientSecret = errors.New("oauth2 client has no secret")
+
 // CreateOAuth2BasicClient creates a new OAuth2 basic (confidential) client
 func (c *Client) CreateOAuth2BasicClient(ctx context.Context, name, displayName, origin string) (*OAuth2Client, error) {
 	req := NewCreateRequest(map[string]any{
@@ -78,41 +134,191 @@ func (c *Client) GetOAuth2Client(ctx context.Context, name string) (*OAuth2Clien
 		return nil, fmt.Errorf("get oauth2 client: %w", err)
 	}
 
-	var entry Entry
+	// Kanidm answers with null rather than 404 when no client matches
+	var entry *Entry
 	if err := decodeResponse(resp, &entry); err != nil {
 		return nil, err
 	}
+	if entry == nil {
+		return nil, fmt.Errorf("get oauth2 client %s: %w", name, ErrNotFound)
+	}
+
+	return oauth2ClientFromEntry(entry, c.Capabilities(ctx)), nil
+}
+
+// GetOAuth2ClientByUUID retrieves an OAuth2 client by its UUID. The OAuth2
+// endpoints only address clients by name, so this resolves the UUID by search.
+func (c *Client) GetOAuth2ClientByUUID(ctx context.Context, uuid string) (*OAuth2Client, error) {
+	clients, err := c.SearchOAuth2Clients(ctx, FilterEq("uuid", uuid))
+	if err != nil {
+		return nil, fmt.Errorf("get oauth2 client: %w", err)
+	}
 
+	if len(clients) == 0 {
+		return nil, fmt.Errorf("get oauth2 client: %w", ErrNotFound)
+	}
+
+	return clients[0], nil
+}
+
+// ListOAuth2Clients retrieves all OAuth2 clients visible to the client
+func (c *Client) ListOAuth2Clients(ctx context.Context) ([]*OAuth2Client, error) {
+	entries, err := c.ListEntries(ctx, EntryKindOAuth2)
+	if err != nil {
+		return nil, err
+	}
+
+	return oauth2ClientsFromEntries(entries, c.Capabilities(ctx)), nil
+}
+
+// SearchOAuth2Clients retrieves the OAuth2 clients matching filter
+func (c *Client) SearchOAuth2Clients(ctx context.Context, filter Filter) ([]*OAuth2Client, error) {
+	entries, err := c.SearchEntries(ctx, FilterAnd(FilterEq("class", "oauth2_resource_server"), filter))
+	if err != nil {
+		return nil, err
+	}
+
+	return oauth2ClientsFromEntries(entries, c.Capabilities(ctx)), nil
+}
+
+// oauth2ClientFromEntry converts a raw entry to an OAuth2Client
+func oauth2ClientFromEntry(entry *Entry, caps *Capabilities) *OAuth2Client {
 	// Determine if public based on oauth2_rs_basic_secret attribute presence
 	// Note: The value is hidden for basic clients, so we check if the key exists in attrs
 	_, hasBasicSecret := entry.Attrs["oauth2_rs_basic_secret"]
 	isPublic := !hasBasicSecret
 
-	// Use 'name' attribute for the name (not oauth2_rs_name which is for internal use)
-	clientName := entry.GetString("name")
+	// Older Kanidm releases stored the name in oauth2_rs_name rather than name
+	clientName := entry.GetString(caps.OAuth2NameAttr())
+	if clientName == "" {
+		clientName = entry.GetString("name")
+	}
 	if clientName == "" {
 		clientName = entry.GetString("oauth2_rs_name")
 	}
 
-	// Get origin and normalize by removing trailing slash if present
-	// (Kanidm adds trailing slash, but Terraform configs typically don't have it)
-	origin := entry.GetString("oauth2_rs_origin")
-	if len(origin) > 0 && origin[len(origin)-1] == '/' {
-		origin = origin[:len(origin)-1]
+	redirectURIs := entry.GetStringSlice("oauth2_rs_origin")
+	for i := range redirectURIs {
+		redirectURIs[i] = normalizeOAuth2URL(redirectURIs[i])
 	}
 
 	return &OAuth2Client{
 		Name:         clientName,
+		UUID:         entry.GetString("uuid"),
 		DisplayName:  entry.GetString("displayname"),
-		Origin:       origin,
-		RedirectURIs: entry.GetStringSlice("oauth2_rs_origin_landing"),
-		ClientID:     clientName,
-		IsPublic:     isPublic,
+		Origin:       normalizeOAuth2URL(entry.GetString("oauth2_rs_origin_landing")),
+		RedirectURIs: redirectURIs,
+		ScopeMaps:    parseOAuth2ScopeMaps(entry.GetStringSlice("oauth2_rs_scope_map")),
+		SupScopeMaps: parseOAuth2ScopeMaps(entry.GetStringSlice("oauth2_rs_sup_scope_map")),
+		ClaimMaps:    parseOAuth2ClaimMaps(entry.GetStringSlice("oauth2_rs_claim_map")),
+		Flags: OAuth2Flags{
+			AllowInsecureClientDisablePKCE: entryBool(entry, oauth2AttrDisablePKCE),
+			LegacyCrypto:                   entryBool(entry, oauth2AttrLegacyCrypto),
+			PreferShortUsername:            entryBool(entry, oauth2AttrPreferShortUsername),
+			StrictRedirectURI:              entryBool(entry, oauth2AttrStrictRedirectURI),
+		},
+		ClientID: clientName,
+		IsPublic: isPublic,
 		// Note: Client secret is never returned in GET responses
-	}, nil
+	}
+}
+
+// normalizeOAuth2URL removes the trailing slash Kanidm adds to URLs without a
+// path, so "https://app.example.com" in configuration matches the stored value
+func normalizeOAuth2URL(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil || u.Path != "/" || u.RawQuery != "" || u.Fragment != "" {
+		return raw
+	}
+	return strings.TrimSuffix(raw, "/")
+}
+
+// entryBool reads a boolean attribute, treating an absent value as false
+func entryBool(entry *Entry, attr string) bool {
+	value, err := strconv.ParseBool(entry.GetString(attr))
+	return err == nil && value
+}
+
+// parseOAuth2ScopeMaps parses scope map values, which Kanidm renders as
+// `group@domain: {"openid", "profile"}`
+func parseOAuth2ScopeMaps(values []string) map[string][]string {
+	maps := make(map[string][]string, len(values))
+	for _, value := range values {
+		group, rest, ok := strings.Cut(value, ":")
+		if !ok {
+			continue
+		}
+
+		rest = strings.TrimSpace(rest)
+		rest = strings.TrimSuffix(strings.TrimPrefix(rest, "{"), "}")
+
+		scopes := []string{}
+		for _, scope := range strings.Split(rest, ",") {
+			scope = strings.TrimSpace(scope)
+			if unquoted, err := strconv.Unquote(scope); err == nil {
+				scope = unquoted
+			}
+			if scope != "" {
+				scopes = append(scopes, scope)
+			}
+		}
+
+		maps[strings.TrimSpace(group)] = scopes
+	}
+	return maps
+}
+
+// parseOAuth2ClaimMaps parses claim map values, which Kanidm renders as
+// `claim:group@domain:separator:"value1<separator>value2"`
+func parseOAuth2ClaimMaps(values []string) []OAuth2ClaimMap {
+	claimMaps := make([]OAuth2ClaimMap, 0, len(values))
+	for _, value := range values {
+		parts := strings.SplitN(value, ":", 4)
+		if len(parts) != 4 {
+			continue
+		}
+
+		join := OAuth2ClaimJoinArray
+		for name, separator := range oauth2ClaimJoinSeparators {
+			if parts[2] == separator {
+				join = name
+			}
+		}
+
+		joined := parts[3]
+		if unquoted, err := strconv.Unquote(joined); err == nil {
+			joined = unquoted
+		}
+
+		claimValues := []string{}
+		for _, v := range strings.Split(joined, oauth2ClaimJoinSeparators[join]) {
+			if v != "" {
+				claimValues = append(claimValues, v)
+			}
+		}
+
+		claimMaps = append(claimMaps, OAuth2ClaimMap{
+			Claim:  parts[0],
+			Group:  parts[1],
+			Join:   join,
+			Values: claimValues,
+		})
+	}
+	return claimMaps
+}
+
+// oauth2ClientsFromEntries converts raw entries to OAuth2Clients
+func oauth2ClientsFromEntries(entries []Entry, caps *Capabilities) []*OAuth2Client {
+	clients := make([]*OAuth2Client, 0, len(entries))
+	for i := range entries {
+		clients = append(clients, oauth2ClientFromEntry(&entries[i], caps))
+	}
+	return clients
 }
 
-// UpdateOAuth2Client updates an OAuth2 client
+// UpdateOAuth2Client updates an OAuth2 client. The origin is the landing
+// page; when no redirect URIs are given the origin is also registered as the
+// only redirect URI.
 func (c *Client) UpdateOAuth2Client(ctx context.Context, name string, displayName, origin string, redirectURIs []string) error {
 	attrs := make(map[string]any)
 
@@ -121,11 +327,14 @@ func (c *Client) UpdateOAuth2Client(ctx context.Context, name string, displayNam
 	}
 
 	if origin != "" {
-		attrs["oauth2_rs_origin"] = []string{origin}
+		attrs["oauth2_rs_origin_landing"] = []string{origin}
 	}
 
-	if redirectURIs != nil {
-		attrs["oauth2_rs_origin_landing"] = redirectURIs
+	switch {
+	case len(redirectURIs) > 0:
+		attrs["oauth2_rs_origin"] = redirectURIs
+	case origin != "":
+		attrs["oauth2_rs_origin"] = []string{origin}
 	}
 
 	req := NewUpdateRequest(attrs)
@@ -173,6 +382,96 @@ func (c *Client) DeleteOAuth2ScopeMap(ctx context.Context, rsName, groupName str
 	return nil
 }
 
+// SetOAuth2SupScopeMap sets the supplementary scopes granted to members of a group
+func (c *Client) SetOAuth2SupScopeMap(ctx context.Context, rsName, groupName string, scopes []string) error {
+	resp, err := c.doRequest(ctx, "POST", fmt.Sprintf("/v1/oauth2/%s/_sup_scopemap/%s", rsName, groupName), scopes)
+	if err != nil {
+		return fmt.Errorf("set oauth2 supplementary scope map: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return nil
+}
+
+// DeleteOAuth2SupScopeMap removes a supplementary scope mapping for an OAuth2 client
+func (c *Client) DeleteOAuth2SupScopeMap(ctx context.Context, rsName, groupName string) error {
+	resp, err := c.doRequest(ctx, "DELETE", fmt.Sprintf("/v1/oauth2/%s/_sup_scopemap/%s", rsName, groupName), nil)
+	if err != nil {
+		return fmt.Errorf("delete oauth2 supplementary scope map: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return nil
+}
+
+// SetOAuth2ClaimMap sets the values of a custom claim for members of a group
+func (c *Client) SetOAuth2ClaimMap(ctx context.Context, rsName, claim, groupName string, values []string) error {
+	if err := c.Require(ctx, FeatureOAuth2ClaimMaps); err != nil {
+		return fmt.Errorf("set oauth2 claim map: %w", err)
+	}
+
+	resp, err := c.doRequest(ctx, "POST", fmt.Sprintf("/v1/oauth2/%s/_claimmap/%s/%s", rsName, claim, groupName), values)
+	if err != nil {
+		return fmt.Errorf("set oauth2 claim map: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return nil
+}
+
+// SetOAuth2ClaimMapJoin sets how multiple values of a custom claim are joined
+func (c *Client) SetOAuth2ClaimMapJoin(ctx context.Context, rsName, claim, join string) error {
+	if err := c.Require(ctx, FeatureOAuth2ClaimMaps); err != nil {
+		return fmt.Errorf("set oauth2 claim map join: %w", err)
+	}
+
+	resp, err := c.doRequest(ctx, "POST", fmt.Sprintf("/v1/oauth2/%s/_claimmap/%s", rsName, claim), join)
+	if err != nil {
+		return fmt.Errorf("set oauth2 claim map join: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return nil
+}
+
+// DeleteOAuth2ClaimMap removes the values of a custom claim for a group
+func (c *Client) DeleteOAuth2ClaimMap(ctx context.Context, rsName, claim, groupName string) error {
+	resp, err := c.doRequest(ctx, "DELETE", fmt.Sprintf("/v1/oauth2/%s/_claimmap/%s/%s", rsName, claim, groupName), nil)
+	if err != nil {
+		return fmt.Errorf("delete oauth2 claim map: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return nil
+}
+
+// UpdateOAuth2Flags changes the boolean settings of an OAuth2 client from
+// their current values to the desired ones. Only settings that differ are
+// sent, so servers without a given setting are unaffected unless it changes.
+func (c *Client) UpdateOAuth2Flags(ctx context.Context, name string, current, desired OAuth2Flags) error {
+	attrs := make(map[string]any)
+	setFlag := func(attr string, from, to bool) {
+		if from != to {
+			attrs[attr] = []string{strconv.FormatBool(to)}
+		}
+	}
+
+	setFlag(oauth2AttrDisablePKCE, current.AllowInsecureClientDisablePKCE, desired.AllowInsecureClientDisablePKCE)
+	setFlag(oauth2AttrLegacyCrypto, current.LegacyCrypto, desired.LegacyCrypto)
+	setFlag(oauth2AttrPreferShortUsername, current.PreferShortUsername, desired.PreferShortUsername)
+	setFlag(oauth2AttrStrictRedirectURI, current.StrictRedirectURI, desired.StrictRedirectURI)
+
+	if len(attrs) == 0 {
+		return nil
+	}
+
+	if err := c.patchEntry(ctx, EntryKindOAuth2, name, attrs); err != nil {
+		return fmt.Errorf("update oauth2 flags: %w", err)
+	}
+
+	return nil
+}
+
 // GetOAuth2BasicSecret retrieves the client secret for a basic OAuth2 client
 func (c *Client) GetOAuth2BasicSecret(ctx context.Context, name string) (string, error) {
 	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("/v1/oauth2/%s/_basic_secret", name), nil)
@@ -180,13 +479,17 @@ func (c *Client) GetOAuth2BasicSecret(ctx context.Context, name string) (string,
 		return "", fmt.Errorf("get oauth2 basic secret: %w", err)
 	}
 
-	// The API returns the secret as a plain JSON string
-	var secret string
+	// The API returns the secret as a plain JSON string, or null for public
+	// clients, which have no secret
+	var secret *string
 	if err := decodeResponse(resp, &secret); err != nil {
 		return "", err
 	}
+	if secret == nil {
+		return "", fmt.Errorf("get oauth2 basic secret %s: %w", name, ErrNoClientSecret)
+	}
 
-	return secret, nil
+	return *secret, nil
 }
 
 // RegenerateOAuth2BasicSecret regenerates the client secret for a basic OAuth2 client
@@ -205,3 +508,78 @@ func (c *Client) RegenerateOAuth2BasicSecret(ctx context.Context, name string) (
 
 	return secret, nil
 }
+
+// Key object actions, written to an entry to rotate or revoke its signing keys
+const (
+	keyActionRotate = "key_action_rotate"
+	keyActionRevoke = "key_action_revoke"
+)
+
+// JWKS is a JSON Web Key Set published for a key object
+type JWKS struct {
+	// JSON is the key set as returned by Kanidm
+	JSON string
+	// KeyIDs are the IDs ("kid") of the keys in the set
+	KeyIDs []string
+}
+
+// RotateOAuth2Keys schedules a new signing key for an OAuth2 client to become
+// active at the given time. Existing keys remain valid for verification.
+func (c *Client) RotateOAuth2Keys(ctx context.Context, name string, at time.Time) error {
+	if err := c.patchEntry(ctx, EntryKindOAuth2, name, map[string]any{
+		keyActionRotate: []string{at.UTC().Format(time.RFC3339)},
+	}); err != nil {
+		return fmt.Errorf("rotate oauth2 keys: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeOAuth2Key revokes a signing key of an OAuth2 client. Tokens signed
+// with the key are no longer accepted.
+func (c *Client) RevokeOAuth2Key(ctx context.Context, name, keyID string) error {
+	if err := c.patchEntry(ctx, EntryKindOAuth2, name, map[string]any{
+		keyActionRevoke: []string{keyID},
+	}); err != nil {
+		return fmt.Errorf("revoke oauth2 key: %w", err)
+	}
+
+	return nil
+}
+
+// GetOAuth2JWKS retrieves the public signing keys of an OAuth2 client from
+// its OpenID Connect discovery endpoint
+func (c *Client) GetOAuth2JWKS(ctx context.Context, name string) (*JWKS, error) {
+	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("/oauth2/openid/%s/public_key.jwk", url.PathEscape(name)), nil)
+	if err != nil {
+		return nil, fmt.Errorf("get oauth2 jwks: %w", err)
+	}
+
+	var raw json.RawMessage
+	if err := decodeResponse(resp, &raw); err != nil {
+		return nil, err
+	}
+
+	return parseJWKS(raw)
+}
+
+// parseJWKS extracts the key IDs from a JSON Web Key Set
+func parseJWKS(raw json.RawMessage) (*JWKS, error) {
+	var set struct {
+		Keys []struct {
+			KeyID string `json:"kid"`
+		} `json:"keys"`
+	}
+	if err := json.Unmarshal(raw, &set); err != nil {
+		return nil, fmt.Errorf("decode jwks: %w", err)
+	}
+
+	jwks := &JWKS{JSON: string(raw)}
+	for _, key := range set.Keys {
+		if key.KeyID != "" {
+			jwks.KeyIDs = append(jwks.KeyIDs, key.KeyID)
+		}
+	}
+
+	return jwks, nil
+}
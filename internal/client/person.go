@@ -3,13 +3,35 @@ package client
 import (
 	"context"
 	"fmt"
+	"time"
 )
 
 // Person represents a Kanidm person account
 type Person struct {
-	ID          string
-	DisplayName string
-	Mail        []string
+	ID            string
+	UUID          string
+	SPN           string
+	DisplayName   string
+	Mail          []string
+	MemberOf      []string // Direct and inherited group memberships
+	AccountExpire string   // RFC 3339 expiry time, empty when the account does not expire
+}
+
+// personAttrAccountExpire is the attribute Kanidm uses to expire accounts
+const personAttrAccountExpire = "account_expire"
+
+// IsLocked reports whether the account has expired at now
+func (p *Person) IsLocked(now time.Time) bool {
+	if p.AccountExpire == "" {
+		return false
+	}
+
+	expire, err := time.Parse(time.RFC3339, p.AccountExpire)
+	if err != nil {
+		return false
+	}
+
+	return !expire.After(now)
 }
 
 // CreatePerson creates a new person account
@@ -39,16 +61,58 @@ func (c *Client) GetPerson(ctx context.Context, id string) (*Person, error) {
 		return nil, fmt.Errorf("get person: %w", err)
 	}
 
-	var entry Entry
+	// Kanidm answers with null rather than 404 when no person matches
+	var entry *Entry
 	if err := decodeResponse(resp, &entry); err != nil {
 		return nil, err
 	}
+	if entry == nil {
+		return nil, fmt.Errorf("get person %s: %w", id, ErrNotFound)
+	}
+
+	return personFromEntry(entry), nil
+}
+
+// ListPersons retrieves all person accounts visible to the client
+func (c *Client) ListPersons(ctx context.Context) ([]*Person, error) {
+	entries, err := c.ListEntries(ctx, EntryKindPerson)
+	if err != nil {
+		return nil, err
+	}
+
+	return personsFromEntries(entries), nil
+}
+
+// SearchPersons retrieves the person accounts matching filter
+func (c *Client) SearchPersons(ctx context.Context, filter Filter) ([]*Person, error) {
+	entries, err := c.SearchEntries(ctx, FilterAnd(FilterEq("class", "person"), filter))
+	if err != nil {
+		return nil, err
+	}
 
+	return personsFromEntries(entries), nil
+}
+
+// personFromEntry converts a raw entry to a Person
+func personFromEntry(entry *Entry) *Person {
 	return &Person{
-		ID:          entry.GetString("name"),
-		DisplayName: entry.GetString("displayname"),
-		Mail:        entry.GetStringSlice("mail"),
-	}, nil
+		ID:            entry.GetString("name"),
+		UUID:          entry.GetString("uuid"),
+		SPN:           entry.GetString("spn"),
+		DisplayName:   entry.GetString("displayname"),
+		Mail:          entry.GetStringSlice("mail"),
+		MemberOf:      entry.GetStringSlice("memberof"),
+		AccountExpire: entry.GetString(personAttrAccountExpire),
+	}
+}
+
+// personsFromEntries converts raw entries to Persons
+func personsFromEntries(entries []Entry) []*Person {
+	persons := make([]*Person, 0, len(entries))
+	for i := range entries {
+		persons = append(persons, personFromEntry(&entries[i]))
+	}
+	return persons
 }
 
 // UpdatePerson updates a person account
@@ -74,38 +138,56 @@ func (c *Client) UpdatePerson(ctx context.Context, id string, displayName string
 	return nil
 }
 
-// DeletePerson deletes a person account
-func (c *Client) DeletePerson(ctx context.Context, id string) error {
-	resp, err := c.doRequest(ctx, "DELETE", "/v1/person/"+id, nil)
-	if err != nil {
-		return fmt.Errorf("delete person: %w", err)
+// LockPerson expires a person account at the given time, so it can no longer
+// authenticate but keeps its credentials and memberships
+func (c *Client) LockPerson(ctx context.Context, id string, at time.Time) error {
+	if err := c.SetEntryAttribute(ctx, EntryKindPerson, id, personAttrAccountExpire, []string{at.UTC().Format(time.RFC3339)}); err != nil {
+		return fmt.Errorf("lock person: %w", err)
 	}
-	defer func() { _ = resp.Body.Close() }()
 
 	return nil
 }
 
-// SetPersonPassword sets the password for a person account
-func (c *Client) SetPersonPassword(ctx context.Context, id, password string) error {
-	// Note: This uses the credential update intent API
-	// Implementation will depend on Kanidm's exact credential management flow
-	req := map[string]any{
-		"password": password,
+// UnlockPerson removes the expiry of a person account. A non-empty restore
+// is an RFC 3339 expiry to set instead, e.g. one scheduled before the account
+// was locked.
+func (c *Client) UnlockPerson(ctx context.Context, id, restore string) error {
+	var err error
+	if restore != "" {
+		err = c.SetEntryAttribute(ctx, EntryKindPerson, id, personAttrAccountExpire, []string{restore})
+	} else {
+		err = c.PurgeEntryAttribute(ctx, EntryKindPerson, id, personAttrAccountExpire)
+	}
+	if err != nil {
+		return fmt.Errorf("unlock person: %w", err)
 	}
 
-	resp, err := c.doRequest(ctx, "POST", fmt.Sprintf("/v1/person/%s/_credential/_update_intent", id), req)
+	return nil
+}
+
+// DeletePerson deletes a person account
+func (c *Client) DeletePerson(ctx context.Context, id string) error {
+	resp, err := c.doRequest(ctx, "DELETE", "/v1/person/"+id, nil)
 	if err != nil {
-		return fmt.Errorf("set person password: %w", err)
+		return fmt.Errorf("delete person: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	return nil
 }
 
+// CredentialResetToken is a single-use token for setting up credentials via
+// the Kanidm web UI, or for starting a credential update session
+type CredentialResetToken struct {
+	Token string `json:"token"`
+	// ExpiryTime is the RFC 3339 time after which the token is no longer valid
+	ExpiryTime string `json:"expiry_time"`
+}
+
 // CreatePersonCredentialResetToken creates a credential reset token for passkey/password setup via UI
 // This enables the modern Kanidm workflow: create person -> generate token -> user sets up credentials
 // The ttl parameter is optional and specifies the token lifetime in seconds
-func (c *Client) CreatePersonCredentialResetToken(ctx context.Context, id string, ttl *int) (string, error) {
+func (c *Client) CreatePersonCredentialResetToken(ctx context.Context, id string, ttl *int) (*CredentialResetToken, error) {
 	path := fmt.Sprintf("/v1/person/%s/_credential/_update_intent", id)
 	if ttl != nil {
 		path = fmt.Sprintf("/v1/person/%s/_credential/_update_intent/%d", id, *ttl)
@@ -113,16 +195,13 @@ func (c *Client) CreatePersonCredentialResetToken(ctx context.Context, id string
 
 	resp, err := c.doRequest(ctx, "GET", path, nil)
 	if err != nil {
-		return "", fmt.Errorf("create credential reset token: %w", err)
-	}
-
-	var result struct {
-		Token string `json:"token"`
+		return nil, fmt.Errorf("create credential reset token: %w", err)
 	}
 
+	var result CredentialResetToken
 	if err := decodeResponse(resp, &result); err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return result.Token, nil
+	return &result, nil
 }
@@ -0,0 +1,25 @@
+package client
+
+import "strings"
+
+// WithProtectedNames sets the names and UUIDs of entries the provider must
+// never delete, such as idm_admins or break-glass accounts
+func WithProtectedNames(names []string) ClientOption {
+	return func(c *Client) {
+		c.protectedNames = make(map[string]bool, len(names))
+		for _, name := range names {
+			c.protectedNames[strings.ToLower(name)] = true
+		}
+	}
+}
+
+// IsProtected reports whether any of ids, a name or UUID of an entry, is
+// listed in the protected names
+func (c *Client) IsProtected(ids ...string) bool {
+	for _, id := range ids {
+		if id != "" && c.protectedNames[strings.ToLower(id)] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,86 @@
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// RadiusToken is what a RADIUS server needs to authenticate an account:
+// its RADIUS secret and the groups used to select a VLAN
+type RadiusToken struct {
+	Name        string             `json:"name"`
+	DisplayName string             `json:"displayname"`
+	UUID        string             `json:"uuid"`
+	Secret      string             `json:"secret"`
+	Groups      []RadiusTokenGroup `json:"groups"`
+}
+
+// RadiusTokenGroup is a group an account belongs to, directly or through
+// nested groups, as reported in its RADIUS token
+type RadiusTokenGroup struct {
+	SPN  string `json:"spn"`
+	UUID string `json:"uuid"`
+}
+
+// GetPersonRadiusSecret retrieves the RADIUS secret of a person. It returns
+// ErrNotFound when the person has no RADIUS secret.
+func (c *Client) GetPersonRadiusSecret(ctx context.Context, id string) (string, error) {
+	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("/v1/person/%s/_radius", id), nil)
+	if err != nil {
+		return "", fmt.Errorf("get radius secret: %w", err)
+	}
+
+	// The secret is returned as a JSON string, or null when none is set
+	var secret *string
+	if err := decodeResponse(resp, &secret); err != nil {
+		return "", err
+	}
+	if secret == nil {
+		return "", fmt.Errorf("get radius secret of %s: %w", id, ErrNotFound)
+	}
+
+	return *secret, nil
+}
+
+// GeneratePersonRadiusSecret generates a new RADIUS secret for a person,
+// replacing any previous one
+func (c *Client) GeneratePersonRadiusSecret(ctx context.Context, id string) (string, error) {
+	resp, err := c.doRequest(ctx, "POST", fmt.Sprintf("/v1/person/%s/_radius", id), nil)
+	if err != nil {
+		return "", fmt.Errorf("generate radius secret: %w", err)
+	}
+
+	var secret string
+	if err := decodeResponse(resp, &secret); err != nil {
+		return "", err
+	}
+
+	return secret, nil
+}
+
+// DeletePersonRadiusSecret removes the RADIUS secret of a person
+func (c *Client) DeletePersonRadiusSecret(ctx context.Context, id string) error {
+	resp, err := c.doRequest(ctx, "DELETE", fmt.Sprintf("/v1/person/%s/_radius", id), nil)
+	if err != nil {
+		return fmt.Errorf("delete radius secret: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return nil
+}
+
+// GetRadiusToken retrieves the RADIUS token of a person or service account.
+// Kanidm returns an error when the account has no RADIUS secret.
+func (c *Client) GetRadiusToken(ctx context.Context, id string) (*RadiusToken, error) {
+	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("/v1/account/%s/_radius/_token", id), nil)
+	if err != nil {
+		return nil, fmt.Errorf("get radius token: %w", err)
+	}
+
+	var token RadiusToken
+	if err := decodeResponse(resp, &token); err != nil {
+		return nil, err
+	}
+
+	return &token, nil
+}
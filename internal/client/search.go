@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Filter is a Kanidm search filter in its JSON protocol form, e.g.
+// {"and": [{"eq": ["class", "person"]}, {"pres": "mail"}]}
+type Filter any
+
+// FilterEq matches entries where attr has the exact value
+func FilterEq(attr, value string) Filter {
+	return map[string]any{"eq": []string{attr, value}}
+}
+
+// FilterContains matches entries where attr contains value as a substring
+func FilterContains(attr, value string) Filter {
+	return map[string]any{"cnt": []string{attr, value}}
+}
+
+// FilterPresent matches entries that have any value for attr
+func FilterPresent(attr string) Filter {
+	return map[string]any{"pres": attr}
+}
+
+// FilterAnd matches entries that satisfy every filter. Nil filters are ignored.
+func FilterAnd(filters ...Filter) Filter {
+	return combineFilters("and", filters)
+}
+
+// FilterOr matches entries that satisfy any filter. Nil filters are ignored.
+func FilterOr(filters ...Filter) Filter {
+	return combineFilters("or", filters)
+}
+
+// FilterAndNot matches entries that do not satisfy filter
+func FilterAndNot(filter Filter) Filter {
+	return map[string]any{"andnot": filter}
+}
+
+// combineFilters joins the non-nil filters with op, collapsing single filters
+func combineFilters(op string, filters []Filter) Filter {
+	nonNil := make([]Filter, 0, len(filters))
+	for _, f := range filters {
+		if f != nil {
+			nonNil = append(nonNil, f)
+		}
+	}
+
+	switch len(nonNil) {
+	case 0:
+		return nil
+	case 1:
+		return nonNil[0]
+	}
+
+	return map[string]any{op: nonNil}
+}
+
+// ParseFilter parses a raw Kanidm filter expression in JSON protocol form
+func ParseFilter(expr string) (Filter, error) {
+	var filter map[string]any
+	if err := json.Unmarshal([]byte(expr), &filter); err != nil {
+		return nil, fmt.Errorf("parse filter: %w", err)
+	}
+
+	if len(filter) != 1 {
+		return nil, fmt.Errorf("parse filter: expected a single operator (eq, cnt, pres, and, or, andnot), got %d", len(filter))
+	}
+
+	for op := range filter {
+		switch op {
+		case "eq", "cnt", "pres", "and", "or", "andnot":
+		default:
+			return nil, fmt.Errorf("parse filter: unknown operator %q", op)
+		}
+	}
+
+	return filter, nil
+}
+
+// ListEntries retrieves every entry of a kind visible to the client.
+// Kanidm returns the complete result set in one response; searches larger
+// than the token's limit_search_max_results fail with ErrCodeResourceLimit.
+func (c *Client) ListEntries(ctx context.Context, kind EntryKind) ([]Entry, error) {
+	resp, err := c.doRequest(ctx, "GET", "/v1/"+string(kind), nil)
+	if err != nil {
+		return nil, fmt.Errorf("list %s: %w", kind, searchError(err))
+	}
+
+	var entries []Entry
+	if err := decodeResponse(resp, &entries); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// SearchEntries retrieves every entry matching filter
+func (c *Client) SearchEntries(ctx context.Context, filter Filter) ([]Entry, error) {
+	req := map[string]any{
+		"filter": filter,
+	}
+
+	resp, err := c.doRequest(ctx, "POST", "/v1/raw/search", req)
+	if err != nil {
+		return nil, fmt.Errorf("search entries: %w", searchError(err))
+	}
+
+	var result struct {
+		Entries []Entry `json:"entries"`
+	}
+
+	if err := decodeResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return result.Entries, nil
+}
+
+// searchError adds guidance to errors caused by search result limits
+func searchError(err error) error {
+	if HasErrorCode(err, ErrCodeResourceLimit) {
+		return fmt.Errorf("%w: the result set exceeds the search limits of the API token, narrow the filter or raise limit_search_max_results for the account", err)
+	}
+	return err
+}
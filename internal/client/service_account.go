@@ -2,12 +2,18 @@ package client
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"strings"
+	"time"
 )
 
 // ServiceAccount represents a Kanidm service account
 type ServiceAccount struct {
 	ID       string
+	UUID     string
+	SPN      string
 	APIToken string // Only populated on creation
 }
 
@@ -28,7 +34,7 @@ func (c *Client) CreateServiceAccount(ctx context.Context, name string) (*Servic
 	}
 
 	// Generate initial API token
-	token, err := c.GenerateServiceAccountToken(ctx, name, "terraform-managed", nil)
+	token, err := c.GenerateServiceAccountToken(ctx, name, "terraform-managed", nil, false)
 	if err != nil {
 		return nil, fmt.Errorf("generate initial token: %w", err)
 	}
@@ -45,15 +51,40 @@ func (c *Client) GetServiceAccount(ctx context.Context, id string) (*ServiceAcco
 		return nil, fmt.Errorf("get service account: %w", err)
 	}
 
-	var entry Entry
+	// Kanidm answers with null rather than 404 when no service account matches
+	var entry *Entry
 	if err := decodeResponse(resp, &entry); err != nil {
 		return nil, err
 	}
+	if entry == nil {
+		return nil, fmt.Errorf("get service account %s: %w", id, ErrNotFound)
+	}
+
+	return serviceAccountFromEntry(entry), nil
+}
+
+// ListServiceAccounts retrieves all service accounts visible to the client
+func (c *Client) ListServiceAccounts(ctx context.Context) ([]*ServiceAccount, error) {
+	entries, err := c.ListEntries(ctx, EntryKindServiceAccount)
+	if err != nil {
+		return nil, err
+	}
 
+	accounts := make([]*ServiceAccount, 0, len(entries))
+	for i := range entries {
+		accounts = append(accounts, serviceAccountFromEntry(&entries[i]))
+	}
+	return accounts, nil
+}
+
+// serviceAccountFromEntry converts a raw entry to a ServiceAccount
+func serviceAccountFromEntry(entry *Entry) *ServiceAccount {
 	return &ServiceAccount{
-		ID: entry.GetString("name"),
+		ID:   entry.GetString("name"),
+		UUID: entry.GetString("uuid"),
+		SPN:  entry.GetString("spn"),
 		// Note: API tokens are not returned in GET responses
-	}, nil
+	}
 }
 
 // UpdateServiceAccount updates a service account
@@ -86,15 +117,17 @@ func (c *Client) DeleteServiceAccount(ctx context.Context, id string) error {
 	return nil
 }
 
-// GenerateServiceAccountToken generates a new API token for the service account
-func (c *Client) GenerateServiceAccountToken(ctx context.Context, id, label string, expiry *int64) (string, error) {
+// GenerateServiceAccountToken generates a new API token for the service account.
+// A nil expiry creates a token that does not expire.
+func (c *Client) GenerateServiceAccountToken(ctx context.Context, id, label string, expiry *time.Time, readWrite bool) (string, error) {
 	req := map[string]any{
-		"label":  label,
-		"expiry": nil,
+		"label":      label,
+		"expiry":     nil,
+		"read_write": readWrite,
 	}
 
 	if expiry != nil {
-		req["expiry"] = *expiry
+		req["expiry"] = expiry.UTC().Format(time.RFC3339)
 	}
 
 	resp, err := c.doRequest(ctx, "POST", fmt.Sprintf("/v1/service_account/%s/_api_token", id), req)
@@ -102,13 +135,48 @@ func (c *Client) GenerateServiceAccountToken(ctx context.Context, id, label stri
 		return "", fmt.Errorf("generate api token: %w", err)
 	}
 
-	var result struct {
-		Token string `json:"token"`
+	// The API returns the token as a plain JSON string
+	var token string
+	if err := decodeResponse(resp, &token); err != nil {
+		return "", err
 	}
 
-	if err := decodeResponse(resp, &result); err != nil {
-		return "", err
+	return token, nil
+}
+
+// APITokenID returns the ID of an API token, read from the claims of the
+// signed token returned by GenerateServiceAccountToken
+func APITokenID(token string) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("parse api token: not a signed token")
 	}
 
-	return result.Token, nil
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("parse api token: %w", err)
+	}
+
+	var claims struct {
+		TokenID string `json:"token_id"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return "", fmt.Errorf("parse api token: %w", err)
+	}
+	if claims.TokenID == "" {
+		return "", fmt.Errorf("parse api token: no token_id claim")
+	}
+
+	return claims.TokenID, nil
+}
+
+// DestroyServiceAccountToken revokes an API token of the service account
+func (c *Client) DestroyServiceAccountToken(ctx context.Context, id, tokenID string) error {
+	resp, err := c.doRequest(ctx, "DELETE", fmt.Sprintf("/v1/service_account/%s/_api_token/%s", id, tokenID), nil)
+	if err != nil {
+		return fmt.Errorf("destroy api token: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return nil
 }
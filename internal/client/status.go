@@ -0,0 +1,118 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// versionHeader is the response header Kanidm uses to report its version
+const versionHeader = "X-KANIDM-VERSION"
+
+// ServerStatus represents the health of a Kanidm server
+type ServerStatus struct {
+	Healthy bool
+	Version string // Empty if the server did not report a version
+}
+
+// Whoami represents the identity the client token authenticates as
+type Whoami struct {
+	Name     string
+	SPN      string
+	UUID     string
+	MemberOf []string
+}
+
+// UserAuthToken represents the session details of the client token
+type UserAuthToken struct {
+	SPN       string
+	UUID      string
+	ReadWrite bool
+	Expiry    *time.Time // Nil if the session does not expire
+}
+
+// GetStatus checks the server health endpoint and records the server version
+func (c *Client) GetStatus(ctx context.Context) (*ServerStatus, error) {
+	resp, err := c.doRequest(ctx, "GET", "/status", nil)
+	if err != nil {
+		return nil, fmt.Errorf("get status: %w", err)
+	}
+
+	version := resp.Header.Get(versionHeader)
+
+	var healthy bool
+	if err := decodeResponse(resp, &healthy); err != nil {
+		return nil, err
+	}
+
+	if version != "" {
+		c.setServerVersion(version)
+	}
+
+	return &ServerStatus{
+		Healthy: healthy,
+		Version: version,
+	}, nil
+}
+
+// Whoami retrieves the entry the client token authenticates as
+func (c *Client) Whoami(ctx context.Context) (*Whoami, error) {
+	resp, err := c.doRequest(ctx, "GET", "/v1/self", nil)
+	if err != nil {
+		return nil, fmt.Errorf("whoami: %w", err)
+	}
+
+	var result struct {
+		YouAre Entry `json:"youare"`
+	}
+
+	if err := decodeResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	return &Whoami{
+		Name:     result.YouAre.GetString("name"),
+		SPN:      result.YouAre.GetString("spn"),
+		UUID:     result.YouAre.GetString("uuid"),
+		MemberOf: result.YouAre.GetStringSlice("memberof"),
+	}, nil
+}
+
+// GetUserAuthToken retrieves the session details of the client token
+func (c *Client) GetUserAuthToken(ctx context.Context) (*UserAuthToken, error) {
+	resp, err := c.doRequest(ctx, "GET", "/v1/self/_uat", nil)
+	if err != nil {
+		return nil, fmt.Errorf("get user auth token: %w", err)
+	}
+
+	var result struct {
+		SPN     string     `json:"spn"`
+		UUID    string     `json:"uuid"`
+		Expiry  *time.Time `json:"expiry"`
+		Purpose any        `json:"purpose"`
+	}
+
+	if err := decodeResponse(resp, &result); err != nil {
+		return nil, err
+	}
+
+	// Purpose is either the string "readonly" or {"readwrite": {"expiry": ...}}
+	readWrite := false
+	if purpose, ok := result.Purpose.(map[string]any); ok {
+		if rw, ok := purpose["readwrite"].(map[string]any); ok {
+			readWrite = true
+			if expiry, ok := rw["expiry"].(string); ok {
+				if t, err := time.Parse(time.RFC3339, expiry); err == nil && time.Now().After(t) {
+					readWrite = false
+				}
+			}
+		}
+	}
+
+	return &UserAuthToken{
+		SPN:       result.SPN,
+		UUID:      result.UUID,
+		ReadWrite: readWrite,
+		Expiry:    result.Expiry,
+	}, nil
+}
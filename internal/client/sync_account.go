@@ -0,0 +1,160 @@
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// SyncAccountAttrYieldAuthority lists the attributes of synchronised entries
+// that Kanidm owns locally instead of the external source
+const SyncAccountAttrYieldAuthority = "sync_yield_authority"
+
+// SyncAccount represents a Kanidm sync account, used by SCIM and LDAP sync
+// tools to import entries from an external identity source
+type SyncAccount struct {
+	ID             string
+	UUID           string
+	Description    string
+	YieldAuthority []string
+}
+
+// CreateSyncAccount creates a new sync account
+func (c *Client) CreateSyncAccount(ctx context.Context, name, description string) (*SyncAccount, error) {
+	attrs := map[string]any{
+		"name": []string{name},
+	}
+	if description != "" {
+		attrs["description"] = []string{description}
+	}
+
+	resp, err := c.doRequest(ctx, "POST", "/v1/sync_account", NewCreateRequest(attrs))
+	if err != nil {
+		return nil, fmt.Errorf("create sync account: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return &SyncAccount{
+		ID:          name,
+		Description: description,
+	}, nil
+}
+
+// GetSyncAccount retrieves a sync account by name or UUID
+func (c *Client) GetSyncAccount(ctx context.Context, id string) (*SyncAccount, error) {
+	resp, err := c.doRequest(ctx, "GET", "/v1/sync_account/"+id, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get sync account: %w", err)
+	}
+
+	// Kanidm answers with null rather than 404 when no sync account matches
+	var entry *Entry
+	if err := decodeResponse(resp, &entry); err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, fmt.Errorf("get sync account %s: %w", id, ErrNotFound)
+	}
+
+	return syncAccountFromEntry(entry), nil
+}
+
+// syncAccountFromEntry converts a raw entry to a SyncAccount
+func syncAccountFromEntry(entry *Entry) *SyncAccount {
+	return &SyncAccount{
+		ID:             entry.GetString("name"),
+		UUID:           entry.GetString("uuid"),
+		Description:    entry.GetString("description"),
+		YieldAuthority: entry.GetStringSlice(SyncAccountAttrYieldAuthority),
+	}
+}
+
+// UpdateSyncAccount replaces the description and yielded attributes of a
+// sync account. An empty description or nil list removes the values.
+func (c *Client) UpdateSyncAccount(ctx context.Context, id, description string, yieldAuthority []string) error {
+	attrs := map[string]any{
+		"description":                 []string{},
+		SyncAccountAttrYieldAuthority: []string{},
+	}
+	if description != "" {
+		attrs["description"] = []string{description}
+	}
+	if yieldAuthority != nil {
+		attrs[SyncAccountAttrYieldAuthority] = yieldAuthority
+	}
+
+	resp, err := c.doRequest(ctx, "PATCH", "/v1/sync_account/"+id, NewUpdateRequest(attrs))
+	if err != nil {
+		return fmt.Errorf("update sync account: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return nil
+}
+
+// GenerateSyncToken issues the token a sync tool authenticates with. A sync
+// account has a single token, so any previous token is revoked.
+func (c *Client) GenerateSyncToken(ctx context.Context, id, label string) (string, error) {
+	resp, err := c.doRequest(ctx, "POST", fmt.Sprintf("/v1/sync_account/%s/_sync_token", id), label)
+	if err != nil {
+		return "", fmt.Errorf("generate sync token: %w", err)
+	}
+
+	// The API returns the token as a plain JSON string
+	var token string
+	if err := decodeResponse(resp, &token); err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// DestroySyncToken revokes the token of a sync account
+func (c *Client) DestroySyncToken(ctx context.Context, id string) error {
+	resp, err := c.doRequest(ctx, "DELETE", fmt.Sprintf("/v1/sync_account/%s/_sync_token", id), nil)
+	if err != nil {
+		return fmt.Errorf("destroy sync token: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return nil
+}
+
+// FinaliseSyncAccount deletes a sync account and converts the entries it
+// synchronised into regular, locally managed entries
+func (c *Client) FinaliseSyncAccount(ctx context.Context, id string) error {
+	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("/v1/sync_account/%s/_finalise", id), nil)
+	if err != nil {
+		return fmt.Errorf("finalise sync account: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return nil
+}
+
+// TerminateSyncAccount deletes a sync account together with every entry it
+// synchronised
+func (c *Client) TerminateSyncAccount(ctx context.Context, id string) error {
+	resp, err := c.doRequest(ctx, "GET", fmt.Sprintf("/v1/sync_account/%s/_terminate", id), nil)
+	if err != nil {
+		return fmt.Errorf("terminate sync account: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return nil
+}
+
+// RenameSyncAccount changes the name of a sync account. The account keeps its
+// UUID, so the entries it synchronised stay attached to it.
+func (c *Client) RenameSyncAccount(ctx context.Context, id, newName string) error {
+	attrs := map[string]any{
+		"name": []string{newName},
+	}
+
+	resp, err := c.doRequest(ctx, "PATCH", "/v1/sync_account/"+id, NewUpdateRequest(attrs))
+	if err != nil {
+		return fmt.Errorf("rename sync account: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return nil
+}
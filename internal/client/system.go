@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"fmt"
+)
+
+// System configuration attribute names
+const (
+	SystemAttrBadlistPassword = "badlist_password"
+	SystemAttrDeniedName      = "denied_name"
+)
+
+// systemAttributeBatchSize caps the values sent per request so large lists,
+// such as a password badlist, stay under the server request size limit
+const systemAttributeBatchSize = 1000
+
+// GetSystemAttribute retrieves the values of a system configuration attribute
+func (c *Client) GetSystemAttribute(ctx context.Context, attr string) ([]string, error) {
+	resp, err := c.doRequest(ctx, "GET", "/v1/system/_attr/"+attr, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get system attribute %s: %w", attr, err)
+	}
+
+	// A missing attribute is returned as null
+	var values []string
+	if err := decodeResponse(resp, &values); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// AddSystemAttributeValues appends values to a system configuration attribute
+func (c *Client) AddSystemAttributeValues(ctx context.Context, attr string, values []string) error {
+	for _, batch := range batches(values, systemAttributeBatchSize) {
+		resp, err := c.doRequest(ctx, "POST", "/v1/system/_attr/"+attr, batch)
+		if err != nil {
+			return fmt.Errorf("add system attribute %s values: %w", attr, err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	return nil
+}
+
+// RemoveSystemAttributeValues removes values from a system configuration attribute
+func (c *Client) RemoveSystemAttributeValues(ctx context.Context, attr string, values []string) error {
+	for _, batch := range batches(values, systemAttributeBatchSize) {
+		resp, err := c.doRequest(ctx, "DELETE", "/v1/system/_attr/"+attr, batch)
+		if err != nil {
+			return fmt.Errorf("remove system attribute %s values: %w", attr, err)
+		}
+		_ = resp.Body.Close()
+	}
+
+	return nil
+}
+
+// PurgeSystemAttribute removes all values of a system configuration attribute
+func (c *Client) PurgeSystemAttribute(ctx context.Context, attr string) error {
+	resp, err := c.doRequest(ctx, "DELETE", "/v1/system/_attr/"+attr, nil)
+	if err != nil {
+		return fmt.Errorf("purge system attribute %s: %w", attr, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	return nil
+}
+
+// batches splits values into slices of at most size elements
+func batches(values []string, size int) [][]string {
+	var out [][]string
+	for len(values) > size {
+		out = append(out, values[:size])
+		values = values[size:]
+	}
+	if len(values) > 0 {
+		out = append(out, values)
+	}
+	return out
+}
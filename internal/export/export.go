@@ -0,0 +1,416 @@
+// Package export generates Terraform configuration and import blocks for the
+// entries of an existing Kanidm instance.
+package export
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ssoriche/terraform-provider-kanidm/internal/client"
+)
+
+// builtinUUIDPrefix is shared by the UUIDs of entries Kanidm creates itself,
+// such as idm_admins and the system accounts
+const builtinUUIDPrefix = "00000000-0000-0000-0000-"
+
+// Run executes the export subcommand with the given command line arguments
+func Run(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	fs := flag.NewFlagSet("export", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+
+	url := fs.String("url", os.Getenv("KANIDM_URL"), "Kanidm server URL (defaults to $KANIDM_URL)")
+	token := fs.String("token", os.Getenv("KANIDM_TOKEN"), "API token with read access (defaults to $KANIDM_TOKEN)")
+	out := fs.String("out", "", "write the configuration to this file instead of stdout")
+	includeBuiltin := fs.Bool("include-builtin", false, "also export entries built into Kanidm, such as idm_admins")
+
+	fs.Usage = func() {
+		_, _ = fmt.Fprintln(stderr, "Usage: terraform-provider-kanidm export [flags]")
+		_, _ = fmt.Fprintln(stderr)
+		_, _ = fmt.Fprintln(stderr, "Writes Terraform configuration and import blocks for the persons, groups,")
+		_, _ = fmt.Fprintln(stderr, "service accounts and OAuth2 clients of an existing Kanidm instance.")
+		_, _ = fmt.Fprintln(stderr)
+		fs.PrintDefaults()
+	}
+
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return err
+	}
+
+	if *url == "" || *token == "" {
+		fs.Usage()
+		return errors.New("both -url and -token (or KANIDM_URL and KANIDM_TOKEN) are required")
+	}
+
+	inv, err := collect(ctx, client.NewClient(*url, *token), *includeBuiltin)
+	if err != nil {
+		return err
+	}
+
+	w := stdout
+	if *out != "" {
+		f, err := os.Create(*out)
+		if err != nil {
+			return fmt.Errorf("create output file: %w", err)
+		}
+		defer func() { _ = f.Close() }()
+		w = f
+	}
+
+	if err := inv.render(w, *url); err != nil {
+		return fmt.Errorf("write configuration: %w", err)
+	}
+
+	_, _ = fmt.Fprintf(stderr, "Exported %d persons, %d service accounts, %d groups and %d OAuth2 clients\n",
+		len(inv.persons), len(inv.serviceAccounts), len(inv.groups), len(inv.oauth2Clients))
+
+	return nil
+}
+
+// inventory holds the entries to export and how to refer to them
+type inventory struct {
+	persons         []*client.Person
+	serviceAccounts []*client.ServiceAccount
+	groups          []*client.Group
+	oauth2Clients   []*client.OAuth2Client
+	publicClients   []string
+
+	// addresses maps the UUID of each exported entry to its resource address
+	addresses map[string]string
+	// uuids maps the names, SPNs and UUIDs of every listed entry to its UUID
+	uuids map[string]string
+	// names maps the UUID of every listed entry to its name
+	names map[string]string
+	// literalMembers holds group to group edges that would form a reference
+	// cycle, keyed by group UUID and member UUID
+	literalMembers map[[2]string]bool
+}
+
+// collect lists every entry kind and prepares them for rendering
+func collect(ctx context.Context, c *client.Client, includeBuiltin bool) (*inventory, error) {
+	persons, err := c.ListPersons(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list persons: %w", err)
+	}
+
+	serviceAccounts, err := c.ListServiceAccounts(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list service accounts: %w", err)
+	}
+
+	groups, err := c.ListGroups(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list groups: %w", err)
+	}
+
+	oauth2Clients, err := c.ListOAuth2Clients(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list oauth2 clients: %w", err)
+	}
+
+	inv := &inventory{
+		addresses:      make(map[string]string),
+		uuids:          make(map[string]string),
+		names:          make(map[string]string),
+		literalMembers: make(map[[2]string]bool),
+	}
+
+	// Index every entry, including skipped ones, so references to them can
+	// still be rendered by name
+	index := func(uuid, name, spn string) {
+		uuid = strings.ToLower(uuid)
+		inv.uuids[uuid] = uuid
+		inv.uuids[name] = uuid
+		if spn != "" {
+			inv.uuids[spn] = uuid
+		}
+		inv.names[uuid] = name
+	}
+	for _, p := range persons {
+		index(p.UUID, p.ID, p.SPN)
+	}
+	for _, sa := range serviceAccounts {
+		index(sa.UUID, sa.ID, sa.SPN)
+	}
+	for _, g := range groups {
+		index(g.UUID, g.ID, g.SPN)
+	}
+
+	exported := func(uuid string) bool {
+		return includeBuiltin || !strings.HasPrefix(strings.ToLower(uuid), builtinUUIDPrefix)
+	}
+
+	labels := make(map[string]bool)
+	address := func(resourceType, uuid, name string) {
+		base := resourceType + "." + label(name)
+		addr := base
+		for i := 2; labels[addr]; i++ {
+			addr = fmt.Sprintf("%s_%d", base, i)
+		}
+		labels[addr] = true
+		inv.addresses[strings.ToLower(uuid)] = addr
+	}
+
+	for _, p := range sortedBy(persons, func(p *client.Person) string { return p.ID }) {
+		if exported(p.UUID) {
+			inv.persons = append(inv.persons, p)
+			address("kanidm_person", p.UUID, p.ID)
+		}
+	}
+	for _, sa := range sortedBy(serviceAccounts, func(sa *client.ServiceAccount) string { return sa.ID }) {
+		if exported(sa.UUID) {
+			inv.serviceAccounts = append(inv.serviceAccounts, sa)
+			address("kanidm_service_account", sa.UUID, sa.ID)
+		}
+	}
+	for _, g := range sortedBy(groups, func(g *client.Group) string { return g.ID }) {
+		if exported(g.UUID) {
+			inv.groups = append(inv.groups, g)
+			address("kanidm_group", g.UUID, g.ID)
+		}
+	}
+	for _, oc := range sortedBy(oauth2Clients, func(oc *client.OAuth2Client) string { return oc.Name }) {
+		if !exported(oc.UUID) {
+			continue
+		}
+		// There is no resource for public clients yet
+		if oc.IsPublic {
+			inv.publicClients = append(inv.publicClients, oc.Name)
+			continue
+		}
+		inv.oauth2Clients = append(inv.oauth2Clients, oc)
+		address("kanidm_oauth2_basic", oc.UUID, oc.Name)
+	}
+
+	inv.breakGroupCycles()
+
+	return inv, nil
+}
+
+// breakGroupCycles finds nested group memberships that would make groups
+// refer to each other in a loop, which Terraform rejects. Those members are
+// rendered as plain names instead of references.
+func (inv *inventory) breakGroupCycles() {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+
+	byUUID := make(map[string]*client.Group, len(inv.groups))
+	for _, g := range inv.groups {
+		byUUID[strings.ToLower(g.UUID)] = g
+	}
+
+	state := make(map[string]int, len(inv.groups))
+	var visit func(uuid string)
+	visit = func(uuid string) {
+		state[uuid] = visiting
+		for _, member := range byUUID[uuid].Members {
+			target, ok := inv.uuids[member]
+			if _, isGroup := byUUID[target]; !ok || !isGroup {
+				continue
+			}
+
+			switch state[target] {
+			case visiting:
+				inv.literalMembers[[2]string{uuid, target}] = true
+			case unvisited:
+				visit(target)
+			}
+		}
+		state[uuid] = done
+	}
+
+	for _, g := range inv.groups {
+		if uuid := strings.ToLower(g.UUID); state[uuid] == unvisited {
+			visit(uuid)
+		}
+	}
+}
+
+// reference renders an entry identifier as a Terraform reference when the
+// entry is exported, and as its name otherwise
+func (inv *inventory) reference(id string) string {
+	uuid, ok := inv.uuids[id]
+	if !ok {
+		return quote(id)
+	}
+
+	if addr, ok := inv.addresses[uuid]; ok {
+		if strings.HasPrefix(addr, "kanidm_oauth2_basic.") {
+			return addr + ".name"
+		}
+		return addr + ".id"
+	}
+
+	return quote(inv.names[uuid])
+}
+
+// render writes the configuration for every exported entry
+func (inv *inventory) render(w io.Writer, url string) error {
+	if _, err := fmt.Fprintf(w, "# Generated by terraform-provider-kanidm export from %s\n", url); err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintln(w, "# Passwords, API tokens and client secrets are not exported. Review before applying."); err != nil {
+		return err
+	}
+
+	var blocks []*block
+	for _, p := range inv.persons {
+		b := &block{Type: "resource", Labels: []string{"kanidm_person", resourceName(inv.addresses[strings.ToLower(p.UUID)])}}
+		b.attr("id", quote(p.ID))
+		b.attr("displayname", quote(p.DisplayName))
+		if len(p.Mail) > 0 {
+			b.attr("mail", quoteList(p.Mail))
+		}
+		if p.IsLocked(time.Now()) {
+			b.attr("locked", "true")
+		}
+		blocks = append(blocks, b, importBlock(inv.addresses[strings.ToLower(p.UUID)], p.UUID))
+	}
+
+	for _, sa := range inv.serviceAccounts {
+		b := &block{Type: "resource", Labels: []string{"kanidm_service_account", resourceName(inv.addresses[strings.ToLower(sa.UUID)])}}
+		b.attr("id", quote(sa.ID))
+		blocks = append(blocks, b, importBlock(inv.addresses[strings.ToLower(sa.UUID)], sa.UUID))
+	}
+
+	for _, g := range inv.groups {
+		uuid := strings.ToLower(g.UUID)
+		b := &block{Type: "resource", Labels: []string{"kanidm_group", resourceName(inv.addresses[uuid])}}
+		b.attr("id", quote(g.ID))
+		if g.Description != "" {
+			b.attr("description", quote(g.Description))
+		}
+		if len(g.Members) > 0 {
+			members := make([]string, 0, len(g.Members))
+			for _, member := range g.Members {
+				if target, ok := inv.uuids[member]; ok && inv.literalMembers[[2]string{uuid, target}] {
+					members = append(members, quote(inv.names[target]))
+					continue
+				}
+				members = append(members, inv.reference(member))
+			}
+			b.attr("members", expressionList(members))
+		}
+		blocks = append(blocks, b, importBlock(inv.addresses[uuid], g.UUID))
+	}
+
+	for _, oc := range inv.oauth2Clients {
+		blocks = append(blocks, inv.oauth2Block(oc), importBlock(inv.addresses[strings.ToLower(oc.UUID)], oc.UUID))
+	}
+
+	for _, b := range blocks {
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+		if err := b.write(w, 0); err != nil {
+			return err
+		}
+	}
+
+	if len(inv.publicClients) > 0 {
+		if _, err := fmt.Fprintf(w, "\n# Public OAuth2 clients are not supported by the provider yet and were skipped: %s\n",
+			strings.Join(inv.publicClients, ", ")); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// oauth2Block renders a kanidm_oauth2_basic resource
+func (inv *inventory) oauth2Block(oc *client.OAuth2Client) *block {
+	b := &block{Type: "resource", Labels: []string{"kanidm_oauth2_basic", resourceName(inv.addresses[strings.ToLower(oc.UUID)])}}
+	b.attr("name", quote(oc.Name))
+	b.attr("displayname", quote(oc.DisplayName))
+	b.attr("origin", quote(oc.Origin))
+	if len(oc.RedirectURIs) > 0 {
+		b.attr("redirect_uris", quoteList(oc.RedirectURIs))
+	}
+
+	flags := []struct {
+		name  string
+		value bool
+	}{
+		{"allow_insecure_client_disable_pkce", oc.Flags.AllowInsecureClientDisablePKCE},
+		{"legacy_crypto", oc.Flags.LegacyCrypto},
+		{"prefer_short_username", oc.Flags.PreferShortUsername},
+		{"strict_redirect_uri", oc.Flags.StrictRedirectURI},
+	}
+	for _, flag := range flags {
+		if flag.value {
+			b.attr(flag.name, "true")
+		}
+	}
+
+	scopeMapBlocks := func(blockType string, maps map[string][]string) {
+		groups := make([]string, 0, len(maps))
+		for group := range maps {
+			groups = append(groups, group)
+		}
+		sort.Strings(groups)
+
+		for _, group := range groups {
+			nested := &block{Type: blockType}
+			nested.attr("group", inv.reference(group))
+			nested.attr("scopes", quoteList(maps[group]))
+			b.Blocks = append(b.Blocks, nested)
+		}
+	}
+	scopeMapBlocks("scope_map", oc.ScopeMaps)
+	scopeMapBlocks("sup_scope_map", oc.SupScopeMaps)
+
+	claimMaps := append([]client.OAuth2ClaimMap(nil), oc.ClaimMaps...)
+	sort.Slice(claimMaps, func(i, j int) bool {
+		if claimMaps[i].Claim != claimMaps[j].Claim {
+			return claimMaps[i].Claim < claimMaps[j].Claim
+		}
+		return claimMaps[i].Group < claimMaps[j].Group
+	})
+	for _, cm := range claimMaps {
+		nested := &block{Type: "claim_map"}
+		nested.attr("claim", quote(cm.Claim))
+		nested.attr("group", inv.reference(cm.Group))
+		nested.attr("values", quoteList(cm.Values))
+		if cm.Join != client.OAuth2ClaimJoinArray {
+			nested.attr("join", quote(cm.Join))
+		}
+		b.Blocks = append(b.Blocks, nested)
+	}
+
+	return b
+}
+
+// importBlock renders an import block for the resource at addr
+func importBlock(addr, uuid string) *block {
+	b := &block{Type: "import"}
+	b.attr("to", addr)
+	b.attr("id", quote(uuid))
+	return b
+}
+
+// resourceName returns the name part of a resource address
+func resourceName(addr string) string {
+	_, name, _ := strings.Cut(addr, ".")
+	return name
+}
+
+// sortedBy returns a copy of items sorted by the given key
+func sortedBy[T any](items []T, key func(T) string) []T {
+	sorted := append([]T(nil), items...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return key(sorted[i]) < key(sorted[j])
+	})
+	return sorted
+}
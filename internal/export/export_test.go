@@ -0,0 +1,86 @@
+package export
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ssoriche/terraform-provider-kanidm/internal/client"
+)
+
+// testInventory builds an inventory of groups whose UUIDs are their names,
+// indexed the way collect indexes them
+func testInventory(groups map[string][]string) *inventory {
+	inv := &inventory{
+		uuids:          make(map[string]string),
+		names:          make(map[string]string),
+		literalMembers: make(map[[2]string]bool),
+	}
+
+	for name := range groups {
+		inv.uuids[name] = name
+		inv.names[name] = name
+	}
+	for _, name := range []string{"a", "b", "c", "d"} {
+		if members, ok := groups[name]; ok {
+			inv.groups = append(inv.groups, &client.Group{ID: name, UUID: name, Members: members})
+		}
+	}
+
+	return inv
+}
+
+func TestBreakGroupCycles(t *testing.T) {
+	tests := []struct {
+		name   string
+		groups map[string][]string
+		want   map[[2]string]bool
+	}{
+		{
+			name:   "no nesting",
+			groups: map[string][]string{"a": {"alice"}, "b": nil},
+			want:   map[[2]string]bool{},
+		},
+		{
+			name:   "chain",
+			groups: map[string][]string{"a": {"b"}, "b": {"c"}, "c": nil},
+			want:   map[[2]string]bool{},
+		},
+		{
+			name:   "diamond",
+			groups: map[string][]string{"a": {"b", "c"}, "b": {"d"}, "c": {"d"}, "d": nil},
+			want:   map[[2]string]bool{},
+		},
+		{
+			name:   "self membership",
+			groups: map[string][]string{"a": {"a"}},
+			want:   map[[2]string]bool{{"a", "a"}: true},
+		},
+		{
+			name:   "two group cycle",
+			groups: map[string][]string{"a": {"b"}, "b": {"a"}},
+			want:   map[[2]string]bool{{"b", "a"}: true},
+		},
+		{
+			name:   "three group cycle",
+			groups: map[string][]string{"a": {"b"}, "b": {"c"}, "c": {"a", "alice"}},
+			want:   map[[2]string]bool{{"c", "a"}: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inv := testInventory(tt.groups)
+			inv.breakGroupCycles()
+
+			if !reflect.DeepEqual(inv.literalMembers, tt.want) {
+				t.Errorf("literalMembers = %v, want %v", inv.literalMembers, tt.want)
+			}
+		})
+	}
+}
+
+func TestResourceName(t *testing.T) {
+	if got := resourceName("kanidm_group.developers_2"); got != "developers_2" {
+		t.Errorf("resourceName() = %q, want %q", got, "developers_2")
+	}
+}
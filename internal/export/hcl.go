@@ -0,0 +1,138 @@
+package export
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// block is an HCL block such as a resource, import or nested block
+type block struct {
+	Type   string
+	Labels []string
+	Attrs  []attribute
+	Blocks []*block
+}
+
+// attribute is an HCL attribute whose value is an already rendered expression
+type attribute struct {
+	Name  string
+	Value string
+}
+
+// attr appends an attribute to the block
+func (b *block) attr(name, value string) {
+	b.Attrs = append(b.Attrs, attribute{Name: name, Value: value})
+}
+
+// write renders the block at the given indentation depth. Attribute names
+// are padded so the equals signs line up, as terraform fmt does.
+func (b *block) write(w io.Writer, depth int) error {
+	indent := strings.Repeat("  ", depth)
+
+	header := b.Type
+	for _, label := range b.Labels {
+		header += " " + quote(label)
+	}
+	if _, err := fmt.Fprintf(w, "%s%s {\n", indent, header); err != nil {
+		return err
+	}
+
+	width := 0
+	for _, a := range b.Attrs {
+		width = max(width, len(a.Name))
+	}
+
+	for _, a := range b.Attrs {
+		value := strings.ReplaceAll(a.Value, "\n", "\n"+indent+"  ")
+		if _, err := fmt.Fprintf(w, "%s  %-*s = %s\n", indent, width, a.Name, value); err != nil {
+			return err
+		}
+	}
+
+	for i, nested := range b.Blocks {
+		if i > 0 || len(b.Attrs) > 0 {
+			if _, err := fmt.Fprintln(w); err != nil {
+				return err
+			}
+		}
+		if err := nested.write(w, depth+1); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "%s}\n", indent)
+	return err
+}
+
+// quote renders s as an HCL string literal, escaping template sequences so
+// values such as "${foo}" are written literally
+func quote(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if unicode.IsControl(r) {
+				fmt.Fprintf(&b, `\u%04x`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+
+	out := strings.ReplaceAll(b.String(), "${", "$${")
+	return strings.ReplaceAll(out, "%{", "%%{")
+}
+
+// quoteList renders strings as an HCL list on a single line
+func quoteList(values []string) string {
+	quoted := make([]string, 0, len(values))
+	for _, v := range values {
+		quoted = append(quoted, quote(v))
+	}
+	return "[" + strings.Join(quoted, ", ") + "]"
+}
+
+// expressionList renders expressions as a multi-line HCL list, one per line
+func expressionList(exprs []string) string {
+	if len(exprs) == 0 {
+		return "[]"
+	}
+
+	sorted := append([]string(nil), exprs...)
+	sort.Strings(sorted)
+	return "[\n  " + strings.Join(sorted, ",\n  ") + ",\n]"
+}
+
+// label converts an entry name into a valid, readable Terraform resource name
+func label(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '_', r == '-':
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+
+	out := b.String()
+	if out == "" || !(out[0] >= 'a' && out[0] <= 'z' || out[0] == '_') {
+		out = "_" + out
+	}
+	return out
+}
@@ -0,0 +1,100 @@
+package export
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestQuote(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "plain", in: "developers", want: `"developers"`},
+		{name: "empty", in: "", want: `""`},
+		{name: "double quote", in: `say "hi"`, want: `"say \"hi\""`},
+		{name: "backslash", in: `C:\kanidm`, want: `"C:\\kanidm"`},
+		{name: "newline and tab", in: "a\nb\tc\r", want: `"a\nb\tc\r"`},
+		{name: "control character", in: "a\x01b", want: `"a\u0001b"`},
+		{name: "interpolation", in: "${var.name}", want: `"$${var.name}"`},
+		{name: "template directive", in: "%{ if true }", want: `"%%{ if true }"`},
+		{name: "lone dollar and percent", in: "100% $5", want: `"100% $5"`},
+		{name: "unicode", in: "Zoë Ångström", want: `"Zoë Ångström"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := quote(tt.in); got != tt.want {
+				t.Errorf("quote(%q) = %s, want %s", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuoteList(t *testing.T) {
+	got := quoteList([]string{"openid", `a"b`})
+	want := `["openid", "a\"b"]`
+	if got != want {
+		t.Errorf("quoteList() = %s, want %s", got, want)
+	}
+}
+
+func TestLabel(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "valid", in: "developers", want: "developers"},
+		{name: "underscore and dash", in: "idm_all-persons", want: "idm_all-persons"},
+		{name: "upper case", in: "Alice", want: "alice"},
+		{name: "spn", in: "alice@idm.example.com", want: "alice_idm_example_com"},
+		{name: "spaces and symbols", in: "ops team (eu)", want: "ops_team__eu_"},
+		{name: "leading digit", in: "1password", want: "_1password"},
+		{name: "leading dash", in: "-svc", want: "_-svc"},
+		{name: "non ascii", in: "zoë", want: "zo_"},
+		{name: "empty", in: "", want: "_"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := label(tt.in); got != tt.want {
+				t.Errorf("label(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBlockWrite(t *testing.T) {
+	b := &block{Type: "resource", Labels: []string{"kanidm_group", "developers"}}
+	b.attr("id", quote("developers"))
+	b.attr("description", quote("${team}"))
+	b.attr("members", expressionList([]string{"kanidm_person.bob.id", "kanidm_person.alice.id"}))
+
+	nested := &block{Type: "scope_map"}
+	nested.attr("group", "kanidm_group.developers.id")
+	b.Blocks = append(b.Blocks, nested)
+
+	var out strings.Builder
+	if err := b.write(&out, 0); err != nil {
+		t.Fatalf("write() error = %v", err)
+	}
+
+	want := `resource "kanidm_group" "developers" {
+  id          = "developers"
+  description = "$${team}"
+  members     = [
+    kanidm_person.alice.id,
+    kanidm_person.bob.id,
+  ]
+
+  scope_map {
+    group = kanidm_group.developers.id
+  }
+}
+`
+	if out.String() != want {
+		t.Errorf("write() =\n%s\nwant\n%s", out.String(), want)
+	}
+}
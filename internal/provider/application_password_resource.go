@@ -0,0 +1,277 @@
+package provider
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ssoriche/terraform-provider-kanidm/internal/client"
+)
+
+// Ensure the implementation satisfies the required interfaces
+var (
+	_ resource.Resource               = (*applicationPasswordResource)(nil)
+	_ resource.ResourceWithModifyPlan = (*applicationPasswordResource)(nil)
+)
+
+// NewApplicationPasswordResource creates a new application password resource
+func NewApplicationPasswordResource() resource.Resource {
+	return &applicationPasswordResource{}
+}
+
+// applicationPasswordResource is the resource implementation
+type applicationPasswordResource struct {
+	client *client.Client
+}
+
+// applicationPasswordResourceModel describes the resource data model
+type applicationPasswordResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	Person             types.String `tfsdk:"person"`
+	Application        types.String `tfsdk:"application"`
+	Label              types.String `tfsdk:"label"`
+	Secret             types.String `tfsdk:"secret"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
+}
+
+// Metadata returns the resource type name
+func (r *applicationPasswordResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_application_password"
+}
+
+// Schema defines the schema for the resource
+func (r *applicationPasswordResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Issues an application password for a person account.
+
+The password lets the person bind to the application over LDAP, so an LDAP-bound service can be
+provisioned end to end. The person must be a member of the application's linked group. Changing
+any argument issues a new password and revokes the previous one.
+
+## Example Usage
+
+` + "```hcl" + `
+resource "kanidm_application_password" "nextcloud_mailer" {
+  person      = kanidm_person.mailer.id
+  application = kanidm_application.mail.id
+  label       = "nextcloud"
+}
+
+output "nextcloud_mail_password" {
+  value     = kanidm_application_password.nextcloud_mailer.secret
+  sensitive = true
+}
+` + "```" + `
+
+**Important:** The secret is only available when the password is issued and is stored in state.
+Application passwords cannot be imported.`,
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "UUID of the application password.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"person": schema.StringAttribute{
+				MarkdownDescription: "Name, SPN or UUID of the person the password is issued to.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"application": schema.StringAttribute{
+				MarkdownDescription: "Name or UUID of the application the password is valid for.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"label": schema.StringAttribute{
+				MarkdownDescription: "Label shown to the person to identify the password.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"secret": schema.StringAttribute{
+				MarkdownDescription: "The application password. **Only available when issued.**",
+				Computed:            true,
+				Sensitive:           true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"deletion_protection": deletionProtectionAttribute(),
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource
+func (r *applicationPasswordResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			"Expected *client.Client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	r.client = c
+}
+
+// ModifyPlan refuses to plan the destruction or replacement of a protected
+// application password
+func (r *applicationPasswordResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPlanProtection(ctx, r.client, req, resp, nil, path.Root("person"), path.Root("application"), path.Root("label"))
+}
+
+// Create issues the application password
+func (r *applicationPasswordResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan applicationPasswordResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Creating application password", map[string]any{
+		"person":      plan.Person.ValueString(),
+		"application": plan.Application.ValueString(),
+		"label":       plan.Label.ValueString(),
+	})
+
+	// Passwords are issued against the application UUID
+	application, err := r.client.GetApplication(ctx, plan.Application.ValueString())
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("application"),
+				"Application Not Found",
+				"No application named "+plan.Application.ValueString()+" exists.",
+			)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Reading Application",
+			"Could not read application: "+err.Error(),
+		)
+		return
+	}
+
+	password, err := r.client.CreateApplicationPassword(ctx, plan.Person.ValueString(), application.UUID, plan.Label.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error Creating Application Password", "Could not create application password: ", err, map[string]path.Path{
+			client.ErrCodeNoMatchingEntries: path.Root("person"),
+			client.ErrCodeAccessDenied:      path.Root("person"),
+		})
+		return
+	}
+
+	plan.ID = types.StringValue(password.UUID)
+	plan.Secret = types.StringValue(password.Secret)
+
+	tflog.Debug(ctx, "Application password created successfully", map[string]any{
+		"id": plan.ID.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read checks that the person and application still exist. Application
+// passwords themselves cannot be read back.
+func (r *applicationPasswordResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state applicationPasswordResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading application password", map[string]any{
+		"id": state.ID.ValueString(),
+	})
+
+	_, err := r.client.GetPerson(ctx, state.Person.ValueString())
+	if err == nil {
+		_, err = r.client.GetApplication(ctx, state.Application.ValueString())
+	}
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			tflog.Warn(ctx, "Person or application not found, removing application password from state", map[string]any{
+				"id": state.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Reading Application Password",
+			"Could not read the person or application of the application password: "+err.Error(),
+		)
+		return
+	}
+
+	state.DeletionProtection = deletionProtectionValue(state.DeletionProtection)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update is never called with changes, as every argument forces a new password
+func (r *applicationPasswordResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan applicationPasswordResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete revokes the application password
+func (r *applicationPasswordResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state applicationPasswordResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Deleting application password", map[string]any{
+		"id": state.ID.ValueString(),
+	})
+
+	checkDeletionProtection(ctx, r.client, req.State, nil, "destroyed", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteApplicationPassword(ctx, state.Person.ValueString(), state.ID.ValueString()); err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			tflog.Warn(ctx, "Application password not found during delete, removing from state", map[string]any{
+				"id": state.ID.ValueString(),
+			})
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Deleting Application Password",
+			"Could not revoke application password: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Application password deleted successfully", map[string]any{
+		"id": state.ID.ValueString(),
+	})
+}
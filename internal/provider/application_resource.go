@@ -0,0 +1,334 @@
+package provider
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ssoriche/terraform-provider-kanidm/internal/client"
+)
+
+// Ensure the implementation satisfies the required interfaces
+var (
+	_ resource.Resource                = (*applicationResource)(nil)
+	_ resource.ResourceWithImportState = (*applicationResource)(nil)
+	_ resource.ResourceWithIdentity    = (*applicationResource)(nil)
+	_ resource.ResourceWithModifyPlan  = (*applicationResource)(nil)
+)
+
+// NewApplicationResource creates a new application resource
+func NewApplicationResource() resource.Resource {
+	return &applicationResource{}
+}
+
+// applicationResource is the resource implementation
+type applicationResource struct {
+	client *client.Client
+}
+
+// applicationResourceModel describes the resource data model
+type applicationResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	UUID               types.String `tfsdk:"uuid"`
+	DisplayName        types.String `tfsdk:"displayname"`
+	LinkedGroup        types.String `tfsdk:"linked_group"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
+}
+
+// Metadata returns the resource type name
+func (r *applicationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_application"
+}
+
+// Schema defines the schema for the resource
+func (r *applicationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Manages a Kanidm application.
+
+Applications let people bind to LDAP-only services, such as mail clients or Nextcloud, with a
+per-application password instead of their primary credentials. Members of the linked group can
+create application passwords for the application; use ` + "`kanidm_application_password`" + ` to issue
+one from Terraform.
+
+## Example Usage
+
+` + "```hcl" + `
+resource "kanidm_group" "mail_users" {
+  id = "mail-users"
+}
+
+resource "kanidm_application" "mail" {
+  id           = "mail"
+  displayname  = "Mail"
+  linked_group = kanidm_group.mail_users.id
+}
+` + "```",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Name of the application. " +
+					"Changing this renames the application in place; it keeps its UUID and application passwords.",
+				Required: true,
+			},
+			"uuid": schema.StringAttribute{
+				MarkdownDescription: "Immutable UUID of the application, used to track it across renames.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"displayname": schema.StringAttribute{
+				MarkdownDescription: "Display name of the application.",
+				Required:            true,
+			},
+			"linked_group": schema.StringAttribute{
+				MarkdownDescription: "Name, SPN or UUID of the group whose members may use the application.",
+				Required:            true,
+			},
+			"deletion_protection": deletionProtectionAttribute(),
+		},
+	}
+}
+
+// IdentitySchema defines the resource identity, keyed on the entry UUID
+func (r *applicationResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = entryIdentitySchema()
+}
+
+// Configure adds the provider configured client to the resource
+func (r *applicationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			"Expected *client.Client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	r.client = c
+}
+
+// ModifyPlan refuses to plan the destruction of a protected application
+func (r *applicationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPlanProtection(ctx, r.client, req, resp, entryNamePaths)
+}
+
+// Create creates the resource and sets the initial Terraform state
+func (r *applicationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan applicationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Creating application", map[string]any{
+		"id": plan.ID.ValueString(),
+	})
+
+	application, err := r.client.CreateApplication(ctx, plan.ID.ValueString(), plan.DisplayName.ValueString(), plan.LinkedGroup.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error Creating Application", "Could not create application: ", err, map[string]path.Path{
+			client.ErrCodeAttributeUniqueness: path.Root("id"),
+			client.ErrCodeValueDenyName:       path.Root("id"),
+			client.ErrCodeNoMatchingEntries:   path.Root("linked_group"),
+		})
+		return
+	}
+
+	createdApplication, err := r.client.GetApplication(ctx, entryRef(types.StringValue(application.UUID), plan.ID))
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Application",
+			"Application was created but could not be read back: "+err.Error(),
+		)
+		return
+	}
+
+	r.setState(ctx, createdApplication, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Application created successfully", map[string]any{
+		"id": plan.ID.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	setEntryIdentity(ctx, resp.Identity, plan.UUID, &resp.Diagnostics)
+}
+
+// Read refreshes the Terraform state with the latest data
+func (r *applicationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state applicationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading application", map[string]any{
+		"id": state.ID.ValueString(),
+	})
+
+	// Look the application up by UUID once known so renames are followed
+	application, err := r.client.GetApplication(ctx, entryRef(state.UUID, state.ID))
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			tflog.Warn(ctx, "Application not found, removing from state", map[string]any{
+				"id": state.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Reading Application",
+			"Could not read application: "+err.Error(),
+		)
+		return
+	}
+
+	r.setState(ctx, application, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.DeletionProtection = deletionProtectionValue(state.DeletionProtection)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	setEntryIdentity(ctx, resp.Identity, state.UUID, &resp.Diagnostics)
+}
+
+// Update updates the resource and sets the updated Terraform state
+func (r *applicationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state applicationResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Updating application", map[string]any{
+		"id": plan.ID.ValueString(),
+	})
+
+	// The name is part of the update, so a rename happens in place
+	if err := r.client.UpdateApplication(ctx, state.UUID.ValueString(), plan.ID.ValueString(), plan.DisplayName.ValueString(), plan.LinkedGroup.ValueString()); err != nil {
+		addAPIError(&resp.Diagnostics, "Error Updating Application", "Could not update application: ", err, map[string]path.Path{
+			client.ErrCodeAttributeUniqueness: path.Root("id"),
+			client.ErrCodeValueDenyName:       path.Root("id"),
+			client.ErrCodeNoMatchingEntries:   path.Root("linked_group"),
+		})
+		return
+	}
+
+	updatedApplication, err := r.client.GetApplication(ctx, state.UUID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Application",
+			"Application was updated but could not be read back: "+err.Error(),
+		)
+		return
+	}
+
+	r.setState(ctx, updatedApplication, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Application updated successfully", map[string]any{
+		"id": plan.ID.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	setEntryIdentity(ctx, resp.Identity, plan.UUID, &resp.Diagnostics)
+}
+
+// Delete deletes the resource and removes the Terraform state
+func (r *applicationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state applicationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Deleting application", map[string]any{
+		"id": state.ID.ValueString(),
+	})
+
+	checkDeletionProtection(ctx, r.client, req.State, entryNamePaths, "destroyed", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteApplication(ctx, state.UUID.ValueString()); err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			tflog.Warn(ctx, "Application not found during delete, removing from state", map[string]any{
+				"id": state.ID.ValueString(),
+			})
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Deleting Application",
+			"Could not delete application: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Application deleted successfully", map[string]any{
+		"id": state.ID.ValueString(),
+	})
+}
+
+// ImportState imports an existing application into Terraform state
+func (r *applicationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Accept the name, the UUID or an identity with the UUID; Read resolves
+	// each of them and replaces the id with the current name
+	importEntryState(ctx, path.Root("id"), req, resp)
+
+	tflog.Debug(ctx, "Imported application", map[string]any{
+		"id": req.ID,
+	})
+}
+
+// setState maps an application read from Kanidm into model. Kanidm reports
+// the linked group by SPN, so it is kept in the configured form when both
+// refer to the same group.
+func (r *applicationResource) setState(ctx context.Context, application *client.Application, model *applicationResourceModel, diags *diag.Diagnostics) {
+	model.ID = types.StringValue(application.ID)
+	model.UUID = types.StringValue(application.UUID)
+	model.DisplayName = types.StringValue(application.DisplayName)
+
+	if application.LinkedGroup == "" {
+		model.LinkedGroup = types.StringValue("")
+		return
+	}
+
+	var preferred []string
+	if !model.LinkedGroup.IsNull() && !model.LinkedGroup.IsUnknown() {
+		preferred = []string{model.LinkedGroup.ValueString()}
+	}
+
+	linkedGroup, err := r.client.NormalizeMembers(ctx, []string{application.LinkedGroup}, preferred)
+	if err != nil {
+		diags.AddError(
+			"Error Reading Application",
+			"Could not resolve the linked group of the application: "+err.Error(),
+		)
+		return
+	}
+	model.LinkedGroup = types.StringValue(linkedGroup[0])
+}
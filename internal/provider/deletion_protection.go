@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ssoriche/terraform-provider-kanidm/internal/client"
+)
+
+// entryNamePaths are the state attributes holding the name and UUID of the
+// entry managed by an entry resource, checked against protected_names
+var entryNamePaths = []path.Path{path.Root("id"), path.Root("uuid")}
+
+// deletionProtectionAttribute returns the deletion_protection attribute
+// shared by every resource
+func deletionProtectionAttribute() schema.BoolAttribute {
+	return schema.BoolAttribute{
+		MarkdownDescription: "Whether Terraform refuses to destroy or replace the resource. Set it to `false` and " +
+			"apply before removing the resource or making a change that forces replacement. Defaults to `false`.",
+		Optional: true,
+		Computed: true,
+		Default:  booldefault.StaticBool(false),
+	}
+}
+
+// deletionProtectionValue returns the deletion_protection value to keep in
+// state. It cannot be read back from Kanidm, so the default is filled in when
+// it is unset, e.g. after import, to keep the first plan empty.
+func deletionProtectionValue(prior types.Bool) types.Bool {
+	if prior.IsNull() {
+		return types.BoolValue(false)
+	}
+	return prior
+}
+
+// checkDeletionProtection refuses to remove a resource whose
+// deletion_protection is set, or whose entry is in the provider
+// protected_names. names are the state attributes naming the entry the
+// resource deletes, if any; outcome completes "cannot be", e.g. "destroyed".
+func checkDeletionProtection(ctx context.Context, c *client.Client, state tfsdk.State, names []path.Path, outcome string, diags *diag.Diagnostics) {
+	var protection types.Bool
+	diags.Append(state.GetAttribute(ctx, path.Root("deletion_protection"), &protection)...)
+	if diags.HasError() {
+		return
+	}
+
+	if protection.ValueBool() {
+		diags.AddAttributeError(
+			path.Root("deletion_protection"),
+			"Deletion Protection Enabled",
+			"The resource has deletion_protection set, so it cannot be "+outcome+". "+
+				"Set deletion_protection to false and apply that change first.",
+		)
+	}
+
+	if c == nil || len(names) == 0 {
+		return
+	}
+
+	ids := make([]string, 0, len(names))
+	for _, p := range names {
+		var id types.String
+		diags.Append(state.GetAttribute(ctx, p, &id)...)
+		ids = append(ids, id.ValueString())
+	}
+	if diags.HasError() {
+		return
+	}
+
+	if c.IsProtected(ids...) {
+		diags.AddError(
+			"Protected Entry",
+			"The entry "+ids[0]+" is listed in the provider protected_names, so it cannot be "+outcome+". "+
+				"Remove it from protected_names first if this is intended.",
+		)
+	}
+}
+
+// checkPlanProtection is called from ModifyPlan so protected resources fail
+// at plan time rather than during apply. It refuses destroy plans, and
+// updates where any attribute in replaces, which force replacement,
+// changes. Replacements requested with -replace are only caught by Delete.
+func checkPlanProtection(ctx context.Context, c *client.Client, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse, names []path.Path, replaces ...path.Path) {
+	// Nothing is removed when the resource is created
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	if req.Plan.Raw.IsNull() {
+		checkDeletionProtection(ctx, c, req.State, names, "destroyed", &resp.Diagnostics)
+		return
+	}
+
+	var changed []string
+	for _, p := range replaces {
+		var planned, prior attr.Value
+		resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, p, &planned)...)
+		resp.Diagnostics.Append(req.State.GetAttribute(ctx, p, &prior)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if !planned.Equal(prior) {
+			changed = append(changed, p.String())
+		}
+	}
+	if len(changed) == 0 {
+		return
+	}
+
+	checkDeletionProtection(ctx, c, req.State, names, "replaced by changing "+strings.Join(changed, ", "), &resp.Diagnostics)
+}
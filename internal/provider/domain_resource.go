@@ -0,0 +1,399 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ssoriche/terraform-provider-kanidm/internal/client"
+)
+
+// Ensure the implementation satisfies the required interfaces
+var (
+	_ resource.Resource                = (*domainResource)(nil)
+	_ resource.ResourceWithImportState = (*domainResource)(nil)
+	_ resource.ResourceWithModifyPlan  = (*domainResource)(nil)
+)
+
+// NewDomainResource creates a new domain resource
+func NewDomainResource() resource.Resource {
+	return &domainResource{}
+}
+
+// domainResource is the resource implementation
+type domainResource struct {
+	client *client.Client
+}
+
+// domainResourceModel describes the resource data model
+type domainResourceModel struct {
+	ID                        types.String `tfsdk:"id"`
+	Name                      types.String `tfsdk:"name"`
+	DisplayName               types.String `tfsdk:"display_name"`
+	LDAPBaseDN                types.String `tfsdk:"ldap_basedn"`
+	LDAPAllowUnixPasswordBind types.Bool   `tfsdk:"ldap_allow_unix_password_bind"`
+	Image                     types.String `tfsdk:"image"`
+	ImageSHA256               types.String `tfsdk:"image_sha256"`
+	DeletionProtection        types.Bool   `tfsdk:"deletion_protection"`
+}
+
+// Metadata returns the resource type name
+func (r *domainResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_domain"
+}
+
+// Schema defines the schema for the resource
+func (r *domainResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Manages the domain-wide settings of a Kanidm instance.
+
+There is a single domain per Kanidm instance, so declare this resource at most once. Only the
+settings that are configured are managed. Removing a setting, or destroying the resource, resets
+it to the Kanidm default.
+
+## Example Usage
+
+` + "```hcl" + `
+resource "kanidm_domain" "this" {
+  display_name                  = "Example Corp"
+  ldap_basedn                   = "dc=example,dc=com"
+  ldap_allow_unix_password_bind = true
+  image                         = "${path.module}/logo.png"
+}
+` + "```",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "UUID of the domain.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Domain name of the Kanidm instance, set in the server configuration.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"display_name": schema.StringAttribute{
+				MarkdownDescription: "Name of the domain shown to users, e.g. on the login page.",
+				Optional:            true,
+			},
+			"ldap_basedn": schema.StringAttribute{
+				MarkdownDescription: "Base DN of the LDAP interface, e.g. `dc=example,dc=com`. " +
+					"Kanidm derives it from the domain name by default.",
+				Optional: true,
+			},
+			"ldap_allow_unix_password_bind": schema.BoolAttribute{
+				MarkdownDescription: "Whether LDAP binds may authenticate with the POSIX (unix) password of an account.",
+				Optional:            true,
+			},
+			"image": schema.StringAttribute{
+				MarkdownDescription: "Path to the image shown on the login page. PNG, JPEG, GIF, SVG and WebP images are accepted. " +
+					"The image is uploaded again when the file content changes.",
+				Optional: true,
+			},
+			"image_sha256": schema.StringAttribute{
+				MarkdownDescription: "SHA-256 of the uploaded image, used to detect changes to the file.",
+				Computed:            true,
+			},
+			"deletion_protection": deletionProtectionAttribute(),
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource
+func (r *domainResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			"Expected *client.Client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	r.client = c
+}
+
+// ModifyPlan refuses to plan resetting protected domain settings, and hashes
+// the configured image so a changed file is uploaded again
+func (r *domainResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPlanProtection(ctx, r.client, req, resp, nil)
+	if req.Plan.Raw.IsNull() || resp.Diagnostics.HasError() {
+		return
+	}
+
+	var image types.String
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("image"), &image)...)
+	if resp.Diagnostics.HasError() || image.IsUnknown() {
+		return
+	}
+
+	hash := types.StringNull()
+	if !image.IsNull() {
+		_, sum, err := readDomainImage(image.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("image"), "Error Reading Image", "Could not read domain image: "+err.Error())
+			return
+		}
+		hash = types.StringValue(sum)
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("image_sha256"), hash)...)
+}
+
+// Create applies the configured domain settings
+func (r *domainResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan domainResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Configuring domain")
+
+	r.apply(ctx, &plan, nil, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.refresh(ctx, &plan, &resp.Diagnostics) {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the managed domain settings
+func (r *domainResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state domainResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading domain")
+
+	if !r.refresh(ctx, &state, &resp.Diagnostics) {
+		return
+	}
+	state.DeletionProtection = deletionProtectionValue(state.DeletionProtection)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update applies changed domain settings and resets removed ones
+func (r *domainResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state domainResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Updating domain")
+
+	r.apply(ctx, &plan, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !r.refresh(ctx, &plan, &resp.Diagnostics) {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete resets the managed domain settings to their defaults
+func (r *domainResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state domainResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Resetting domain settings")
+
+	checkDeletionProtection(ctx, r.client, req.State, nil, "destroyed", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	r.apply(ctx, &domainResourceModel{
+		DisplayName:               types.StringNull(),
+		LDAPBaseDN:                types.StringNull(),
+		LDAPAllowUnixPasswordBind: types.BoolNull(),
+		Image:                     types.StringNull(),
+	}, &state, &resp.Diagnostics)
+}
+
+// ImportState adopts the current domain settings, which are all managed
+// from then on
+func (r *domainResource) ImportState(ctx context.Context, _ resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	domain, err := r.client.GetDomain(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Domain",
+			"Could not read domain: "+err.Error(),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &domainResourceModel{
+		ID:                        types.StringValue(domain.UUID),
+		Name:                      types.StringValue(domain.Name),
+		DisplayName:               types.StringValue(domain.DisplayName),
+		LDAPBaseDN:                types.StringValue(domain.LDAPBaseDN),
+		LDAPAllowUnixPasswordBind: types.BoolValue(domain.LDAPAllowUnixPasswordBind),
+		Image:                     types.StringNull(),
+		ImageSHA256:               types.StringNull(),
+		DeletionProtection:        types.BoolValue(false),
+	})...)
+}
+
+// apply sets the configured settings that differ from prior, and resets
+// settings that are no longer configured. prior is nil on create.
+func (r *domainResource) apply(ctx context.Context, plan, prior *domainResourceModel, diags *diag.Diagnostics) {
+	if prior == nil {
+		prior = &domainResourceModel{
+			DisplayName:               types.StringNull(),
+			LDAPBaseDN:                types.StringNull(),
+			LDAPAllowUnixPasswordBind: types.BoolNull(),
+			Image:                     types.StringNull(),
+		}
+	}
+
+	settings := []struct {
+		name    string
+		attr    string
+		value   types.String
+		changed bool
+	}{
+		{"display_name", client.DomainAttrDisplayName, plan.DisplayName, !plan.DisplayName.Equal(prior.DisplayName)},
+		{"ldap_basedn", client.DomainAttrLDAPBaseDN, plan.LDAPBaseDN, !plan.LDAPBaseDN.Equal(prior.LDAPBaseDN)},
+		{
+			"ldap_allow_unix_password_bind", client.DomainAttrLDAPAllowUnixPasswords,
+			boolString(plan.LDAPAllowUnixPasswordBind),
+			!plan.LDAPAllowUnixPasswordBind.Equal(prior.LDAPAllowUnixPasswordBind),
+		},
+	}
+
+	for _, setting := range settings {
+		if !setting.changed {
+			continue
+		}
+
+		var err error
+		if setting.value.IsNull() {
+			tflog.Debug(ctx, "Resetting domain setting", map[string]any{"attribute": setting.attr})
+			err = r.client.PurgeDomainAttribute(ctx, setting.attr)
+		} else {
+			tflog.Debug(ctx, "Setting domain setting", map[string]any{"attribute": setting.attr})
+			err = r.client.SetDomainAttribute(ctx, setting.attr, []string{setting.value.ValueString()})
+		}
+		if err != nil {
+			addAPIError(diags, "Error Updating Domain", "Could not update domain setting "+setting.name+": ", err, map[string]path.Path{
+				client.ErrCodeInvalidAttribute: path.Root(setting.name),
+				client.ErrCodeSchemaViolation:  path.Root(setting.name),
+			})
+			return
+		}
+	}
+
+	switch {
+	case !plan.Image.IsNull() && (!plan.Image.Equal(prior.Image) || !plan.ImageSHA256.Equal(prior.ImageSHA256)):
+		data, sum, err := readDomainImage(plan.Image.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("image"), "Error Reading Image", "Could not read domain image: "+err.Error())
+			return
+		}
+
+		tflog.Debug(ctx, "Uploading domain image", map[string]any{"image": plan.Image.ValueString()})
+		if err := r.client.SetDomainImage(ctx, plan.Image.ValueString(), data); err != nil {
+			addAPIError(diags, "Error Updating Domain", "Could not upload domain image: ", err, map[string]path.Path{
+				client.ErrCodeInvalidAttribute: path.Root("image"),
+			})
+			return
+		}
+		plan.ImageSHA256 = types.StringValue(sum)
+	case plan.Image.IsNull() && !prior.Image.IsNull():
+		tflog.Debug(ctx, "Removing domain image")
+		if err := r.client.DeleteDomainImage(ctx); err != nil {
+			diags.AddError("Error Updating Domain", "Could not remove domain image: "+err.Error())
+			return
+		}
+		plan.ImageSHA256 = types.StringNull()
+	}
+}
+
+// refresh reads the domain and updates the managed settings in model.
+// Settings that are not configured stay null. It reports whether the read
+// succeeded.
+func (r *domainResource) refresh(ctx context.Context, model *domainResourceModel, diags *diag.Diagnostics) bool {
+	domain, err := r.client.GetDomain(ctx)
+	if err != nil {
+		diags.AddError(
+			"Error Reading Domain",
+			"Could not read domain: "+err.Error(),
+		)
+		return false
+	}
+
+	model.ID = types.StringValue(domain.UUID)
+	model.Name = types.StringValue(domain.Name)
+
+	if !model.DisplayName.IsNull() {
+		model.DisplayName = types.StringValue(domain.DisplayName)
+	}
+	if !model.LDAPBaseDN.IsNull() {
+		model.LDAPBaseDN = types.StringValue(domain.LDAPBaseDN)
+	}
+	if !model.LDAPAllowUnixPasswordBind.IsNull() {
+		model.LDAPAllowUnixPasswordBind = types.BoolValue(domain.LDAPAllowUnixPasswordBind)
+	}
+
+	// The image cannot be read back; keep the hash of the uploaded file
+	if model.ImageSHA256.IsUnknown() {
+		model.ImageSHA256 = types.StringNull()
+	}
+
+	return true
+}
+
+// readDomainImage reads an image file and returns its content and SHA-256
+func readDomainImage(name string) ([]byte, string, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, "", err
+	}
+
+	sum := sha256.Sum256(data)
+	return data, hex.EncodeToString(sum[:]), nil
+}
+
+// boolString renders a bool value as the string Kanidm stores, keeping null
+// values null
+func boolString(value types.Bool) types.String {
+	if value.IsNull() || value.IsUnknown() {
+		return types.StringNull()
+	}
+	return types.StringValue(strconv.FormatBool(value.ValueBool()))
+}
@@ -0,0 +1,583 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ssoriche/terraform-provider-kanidm/internal/client"
+)
+
+var (
+	_ resource.Resource                   = (*entryAttributesResource)(nil)
+	_ resource.ResourceWithImportState    = (*entryAttributesResource)(nil)
+	_ resource.ResourceWithValidateConfig = (*entryAttributesResource)(nil)
+	_ resource.ResourceWithModifyPlan     = (*entryAttributesResource)(nil)
+)
+
+// entryAttributeValuesType is the element type of the attributes map
+var entryAttributeValuesType = types.SetType{ElemType: types.StringType}
+
+func NewEntryAttributesResource() resource.Resource {
+	return &entryAttributesResource{}
+}
+
+type entryAttributesResource struct {
+	client *client.Client
+}
+
+type entryAttributesResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	Kind               types.String `tfsdk:"kind"`
+	EntryID            types.String `tfsdk:"entry_id"`
+	Attributes         types.Map    `tfsdk:"attributes"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
+}
+
+func (r *entryAttributesResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_entry_attributes"
+}
+
+func (r *entryAttributesResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Manages arbitrary attributes on an existing Kanidm entry.
+
+This is an escape hatch for attributes the provider does not model yet. Only the attributes listed
+in ` + "`attributes`" + ` are managed; every other attribute on the entry is left untouched.
+Removing an attribute from the map purges it from the entry.
+
+## Example Usage
+
+` + "```hcl" + `
+resource "kanidm_entry_attributes" "alice_extra" {
+  kind     = "person"
+  entry_id = kanidm_person.alice.id
+
+  attributes = {
+    legalname = ["Alice Margaret Smith"]
+  }
+}
+` + "```" + `
+
+**Important:** Do not manage an attribute here that another resource already manages (for example
+` + "`mail`" + ` on ` + "`kanidm_person`" + `), or the two resources will fight over its value.`,
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Identifier of the form `<kind>/<entry_id>`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"kind": schema.StringAttribute{
+				MarkdownDescription: "Kind of the target entry: `person`, `group`, `service_account` or `oauth2`.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"entry_id": schema.StringAttribute{
+				MarkdownDescription: "Name or UUID of the target entry.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"attributes": schema.MapAttribute{
+				MarkdownDescription: "Map of attribute name to the complete set of values for that attribute. " +
+					"Each set must have at least one value; remove an attribute from the map to purge it.",
+				Required:    true,
+				ElementType: entryAttributeValuesType,
+			},
+			"deletion_protection": deletionProtectionAttribute(),
+		},
+	}
+}
+
+func (r *entryAttributesResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config entryAttributesResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// An empty set cannot be read back, as Kanidm drops attributes without
+	// values, so it would show up as a change on every plan
+	if !config.Attributes.IsNull() && !config.Attributes.IsUnknown() {
+		for name, value := range config.Attributes.Elements() {
+			if set, ok := value.(types.Set); ok && !set.IsNull() && !set.IsUnknown() && len(set.Elements()) == 0 {
+				resp.Diagnostics.AddAttributeError(
+					path.Root("attributes").AtMapKey(name),
+					"Empty Attribute Values",
+					"The attribute "+name+" has no values. Remove it from attributes to purge it from the entry.",
+				)
+			}
+		}
+	}
+
+	if config.Kind.IsNull() || config.Kind.IsUnknown() {
+		return
+	}
+
+	if _, err := client.ParseEntryKind(config.Kind.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("kind"),
+			"Invalid Entry Kind",
+			"The kind must be one of: "+entryKindNames()+".",
+		)
+	}
+}
+
+func (r *entryAttributesResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			"Expected *client.Client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	r.client = c
+}
+
+func (r *entryAttributesResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPlanProtection(ctx, r.client, req, resp, nil, path.Root("kind"), path.Root("entry_id"))
+}
+
+func (r *entryAttributesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan entryAttributesResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	kind := client.EntryKind(plan.Kind.ValueString())
+	entryID := plan.EntryID.ValueString()
+
+	attrs, diags := entryAttributesFromMap(ctx, plan.Attributes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Creating entry attributes", map[string]any{
+		"kind":     string(kind),
+		"entry_id": entryID,
+		"count":    len(attrs),
+	})
+
+	for name, values := range attrs {
+		if err := r.client.SetEntryAttribute(ctx, kind, entryID, name, values); err != nil {
+			addAPIError(&resp.Diagnostics, "Error Setting Entry Attribute", "Could not set attribute "+name+": ", err, map[string]path.Path{
+				client.ErrCodeSchemaViolation:      path.Root("attributes").AtMapKey(name),
+				client.ErrCodeInvalidAttribute:     path.Root("attributes").AtMapKey(name),
+				client.ErrCodeInvalidAttributeName: path.Root("attributes").AtMapKey(name),
+			})
+			return
+		}
+	}
+
+	plan.ID = types.StringValue(string(kind) + "/" + entryID)
+
+	if r.refresh(ctx, &plan, true, &resp.Diagnostics) {
+		addEntryGoneError(&resp.Diagnostics, kind, entryID)
+		return
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Entry attributes created successfully", map[string]any{
+		"id": plan.ID.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *entryAttributesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state entryAttributesResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading entry attributes", map[string]any{
+		"id": state.ID.ValueString(),
+	})
+
+	if r.refresh(ctx, &state, false, &resp.Diagnostics) {
+		tflog.Warn(ctx, "Entry not found, removing attributes from state", map[string]any{
+			"id": state.ID.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.DeletionProtection = deletionProtectionValue(state.DeletionProtection)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+func (r *entryAttributesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state entryAttributesResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	kind := client.EntryKind(plan.Kind.ValueString())
+	entryID := plan.EntryID.ValueString()
+
+	oldAttrs, diags := entryAttributesFromMap(ctx, state.Attributes)
+	resp.Diagnostics.Append(diags...)
+	newAttrs, diags := entryAttributesFromMap(ctx, plan.Attributes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Updating entry attributes", map[string]any{
+		"id": state.ID.ValueString(),
+	})
+
+	// Purge attributes that are no longer managed
+	for name := range oldAttrs {
+		if _, exists := newAttrs[name]; exists {
+			continue
+		}
+		if err := r.client.PurgeEntryAttribute(ctx, kind, entryID, name); err != nil && !errors.Is(err, client.ErrNotFound) {
+			resp.Diagnostics.AddError(
+				"Error Removing Entry Attribute",
+				"Could not remove attribute "+name+": "+err.Error(),
+			)
+			return
+		}
+	}
+
+	// Set attributes whose values changed
+	for name, values := range newAttrs {
+		if equalStringSets(oldAttrs[name], values) {
+			continue
+		}
+		if err := r.client.SetEntryAttribute(ctx, kind, entryID, name, values); err != nil {
+			addAPIError(&resp.Diagnostics, "Error Setting Entry Attribute", "Could not set attribute "+name+": ", err, map[string]path.Path{
+				client.ErrCodeSchemaViolation:      path.Root("attributes").AtMapKey(name),
+				client.ErrCodeInvalidAttribute:     path.Root("attributes").AtMapKey(name),
+				client.ErrCodeInvalidAttributeName: path.Root("attributes").AtMapKey(name),
+			})
+			return
+		}
+	}
+
+	plan.ID = state.ID
+
+	if r.refresh(ctx, &plan, true, &resp.Diagnostics) {
+		addEntryGoneError(&resp.Diagnostics, kind, entryID)
+		return
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Entry attributes updated successfully", map[string]any{
+		"id": plan.ID.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+func (r *entryAttributesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state entryAttributesResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	kind := client.EntryKind(state.Kind.ValueString())
+	entryID := state.EntryID.ValueString()
+
+	attrs, diags := entryAttributesFromMap(ctx, state.Attributes)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Deleting entry attributes", map[string]any{
+		"id": state.ID.ValueString(),
+	})
+
+	checkDeletionProtection(ctx, r.client, req.State, nil, "destroyed", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for name := range attrs {
+		if err := r.client.PurgeEntryAttribute(ctx, kind, entryID, name); err != nil {
+			if errors.Is(err, client.ErrNotFound) {
+				tflog.Warn(ctx, "Entry not found during delete, removing from state", map[string]any{
+					"id": state.ID.ValueString(),
+				})
+				return
+			}
+
+			resp.Diagnostics.AddError(
+				"Error Removing Entry Attribute",
+				"Could not remove attribute "+name+": "+err.Error(),
+			)
+			return
+		}
+	}
+
+	tflog.Debug(ctx, "Entry attributes deleted successfully", map[string]any{
+		"id": state.ID.ValueString(),
+	})
+}
+
+func (r *entryAttributesResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Import ID format: <kind>/<entry_id>[/<attr>,<attr>...]
+	parts := strings.SplitN(req.ID, "/", 3)
+	if len(parts) < 2 || parts[1] == "" {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			"Expected an import ID of the form <kind>/<entry_id> or <kind>/<entry_id>/<attr>,<attr>, got: "+req.ID,
+		)
+		return
+	}
+	kindName, entryID := parts[0], parts[1]
+
+	if _, err := client.ParseEntryKind(kindName); err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Import ID",
+			"The kind must be one of: "+entryKindNames()+".",
+		)
+		return
+	}
+
+	// Listed attributes become managed keys; Read fills in their values
+	managed := make(map[string]attr.Value)
+	if len(parts) == 3 {
+		for _, name := range strings.Split(parts[2], ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				managed[name] = types.SetValueMust(types.StringType, []attr.Value{})
+			}
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), kindName+"/"+entryID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("kind"), kindName)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("entry_id"), entryID)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("attributes"), types.MapValueMust(entryAttributeValuesType, managed))...)
+
+	tflog.Debug(ctx, "Imported entry attributes", map[string]any{
+		"id":         req.ID,
+		"attributes": len(managed),
+	})
+
+	if len(managed) == 0 {
+		resp.Diagnostics.AddWarning(
+			"No Attributes Imported",
+			"Import only records the target entry. The attributes to manage are taken from configuration on the next apply. "+
+				"List them in the import ID, e.g. "+kindName+"/"+entryID+"/loginshell,gidnumber, to read their current values.",
+		)
+	}
+}
+
+// refresh reads the managed attributes back from the server into model.
+// Values Kanidm reports in a normalised form, e.g. a reference as an SPN or
+// a lowercased name, keep the form in model so they do not show as drift.
+// After a write, afterApply keeps every planned value, as Terraform requires
+// the applied state to match the plan; differences are only warned about and
+// left for the next Read. It returns true if the entry no longer exists.
+func (r *entryAttributesResource) refresh(ctx context.Context, model *entryAttributesResourceModel, afterApply bool, diags *diag.Diagnostics) bool {
+	entry, err := r.client.GetEntry(ctx, client.EntryKind(model.Kind.ValueString()), model.EntryID.ValueString())
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			return true
+		}
+
+		diags.AddError(
+			"Error Reading Entry",
+			"Could not read entry: "+err.Error(),
+		)
+		return false
+	}
+
+	managed, d := entryAttributesFromMap(ctx, model.Attributes)
+	diags.Append(d...)
+	if diags.HasError() {
+		return false
+	}
+
+	prior := model.Attributes.Elements()
+	values := make(map[string]attr.Value, len(managed))
+	for name, want := range managed {
+		current := entry.GetStringSlice(name)
+		if equivalentAttributeValues(want, current) {
+			values[name] = prior[name]
+			continue
+		}
+
+		if afterApply {
+			// Write-only and hidden attributes read back empty, which is expected
+			if len(current) > 0 {
+				diags.AddAttributeWarning(
+					path.Root("attributes").AtMapKey(name),
+					"Attribute Stored Differently",
+					"Kanidm stored "+strings.Join(current, ", ")+" for "+name+" rather than the configured values. "+
+						"The next plan will show the difference.",
+				)
+			}
+			values[name] = prior[name]
+			continue
+		}
+
+		// Attributes missing on the server are dropped so the next plan
+		// shows them being set again
+		if len(current) == 0 {
+			continue
+		}
+
+		set, d := types.SetValueFrom(ctx, types.StringType, current)
+		diags.Append(d...)
+		if diags.HasError() {
+			return false
+		}
+		values[name] = set
+	}
+
+	attrsMap, d := types.MapValue(entryAttributeValuesType, values)
+	diags.Append(d...)
+	model.Attributes = attrsMap
+
+	return false
+}
+
+// addEntryGoneError reports an entry that disappeared while its attributes
+// were being written
+func addEntryGoneError(diags *diag.Diagnostics, kind client.EntryKind, entryID string) {
+	diags.AddError(
+		"Entry Not Found",
+		"The "+string(kind)+" "+entryID+" no longer exists, so its attributes could not be read back after they were written.",
+	)
+}
+
+// entryAttributesFromMap converts the attributes map to plain Go values
+func entryAttributesFromMap(ctx context.Context, m types.Map) (map[string][]string, diag.Diagnostics) {
+	result := make(map[string][]string)
+	if m.IsNull() || m.IsUnknown() {
+		return result, nil
+	}
+
+	var sets map[string]types.Set
+	diags := m.ElementsAs(ctx, &sets, false)
+	if diags.HasError() {
+		return nil, diags
+	}
+
+	for name, set := range sets {
+		var values []string
+		diags.Append(set.ElementsAs(ctx, &values, false)...)
+		if diags.HasError() {
+			return nil, diags
+		}
+		result[name] = values
+	}
+
+	return result, diags
+}
+
+// equalStringSets reports whether a and b contain the same values
+func equalStringSets(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	seen := make(map[string]int, len(a))
+	for _, v := range a {
+		seen[v]++
+	}
+	for _, v := range b {
+		if seen[v] == 0 {
+			return false
+		}
+		seen[v]--
+	}
+
+	return true
+}
+
+// equivalentAttributeValues reports whether Kanidm's values for an attribute
+// match the configured ones once Kanidm's normalisation is accounted for:
+// case and whitespace are ignored, and a name matches the SPN Kanidm reports
+// for a reference, e.g. "admins" and "admins@idm.example.com".
+func equivalentAttributeValues(want, got []string) bool {
+	if len(want) != len(got) {
+		return false
+	}
+
+	used := make([]bool, len(got))
+	for _, w := range want {
+		found := false
+		for i, g := range got {
+			if !used[i] && equivalentAttributeValue(w, g) {
+				used[i] = true
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// equivalentAttributeValue compares one configured value with Kanidm's
+func equivalentAttributeValue(want, got string) bool {
+	want = strings.ToLower(strings.Join(strings.Fields(want), " "))
+	got = strings.ToLower(strings.Join(strings.Fields(got), " "))
+	if want == got {
+		return true
+	}
+
+	// A reference configured by name is reported as an SPN
+	if !strings.Contains(want, "@") {
+		if local, _, ok := strings.Cut(got, "@"); ok && local == want {
+			return true
+		}
+	}
+
+	return false
+}
+
+// entryKindNames returns the supported entry kinds as a sorted, comma separated list
+func entryKindNames() string {
+	names := make([]string, 0, len(client.EntryKinds))
+	for _, kind := range client.EntryKinds {
+		names = append(names, string(kind))
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
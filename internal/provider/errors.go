@@ -0,0 +1,52 @@
+package provider
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/ssoriche/terraform-provider-kanidm/internal/client"
+)
+
+// apiErrorHints adds guidance for Kanidm error codes users commonly hit
+var apiErrorHints = map[string]string{
+	client.ErrCodeAttributeUniqueness: "The value is already in use. Kanidm shares a single name namespace " +
+		"across persons, groups, service accounts and OAuth2 clients.",
+	client.ErrCodePasswordQuality: "Kanidm rejected the password as too weak or present in the password badlist.",
+	client.ErrCodeValueDenyName:   "The name is on the Kanidm denied names list.",
+	client.ErrCodeSchemaViolation: "The request does not satisfy the Kanidm schema for this entry.",
+	client.ErrCodeAccessDenied:    "The provider token does not have permission to perform this operation.",
+	client.ErrCodeReferenceLoop:   "The change would introduce a reference loop, e.g. a group that is a member of itself.",
+}
+
+// addAPIError appends an error diagnostic for a failed Kanidm API call.
+// When the error carries a Kanidm code listed in attrs, the diagnostic is
+// attached to that attribute so Terraform can point at the offending value.
+func addAPIError(diags *diag.Diagnostics, summary, detail string, err error, attrs map[string]path.Path) {
+	detail += err.Error()
+
+	var featureErr *client.UnsupportedFeatureError
+	if errors.As(err, &featureErr) {
+		diags.AddError(summary, detail+"\n\nUpgrade the Kanidm server or remove the configuration that uses this feature.")
+		return
+	}
+
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code == "" {
+		diags.AddError(summary, detail)
+		return
+	}
+
+	code := strings.ToLower(apiErr.Code)
+	if hint, ok := apiErrorHints[code]; ok {
+		detail += "\n\n" + hint
+	}
+
+	if attrPath, ok := attrs[code]; ok {
+		diags.AddAttributeError(attrPath, summary, detail)
+		return
+	}
+
+	diags.AddError(summary, detail)
+}
@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ssoriche/terraform-provider-kanidm/internal/client"
+)
+
+var _ datasource.DataSourceWithConfigure = (*groupDataSource)(nil)
+
+func NewGroupDataSource() datasource.DataSource {
+	return &groupDataSource{}
+}
+
+type groupDataSource struct {
+	client *client.Client
+}
+
+type groupDataSourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	UUID        types.String `tfsdk:"uuid"`
+	SPN         types.String `tfsdk:"spn"`
+	Description types.String `tfsdk:"description"`
+	Members     types.Set    `tfsdk:"members"`
+	GIDNumber   types.Int64  `tfsdk:"gidnumber"`
+}
+
+func (d *groupDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group"
+}
+
+func (d *groupDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Looks up an existing Kanidm group.
+
+## Example Usage
+
+` + "```hcl" + `
+data "kanidm_group" "admins" {
+  id = "idm_admins"
+}
+
+output "admin_members" {
+  value = data.kanidm_group.admins.members
+}
+` + "```",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Name, SPN or UUID of the group to look up.",
+				Required:            true,
+			},
+			"uuid": schema.StringAttribute{
+				MarkdownDescription: "Immutable UUID of the group.",
+				Computed:            true,
+			},
+			"spn": schema.StringAttribute{
+				MarkdownDescription: "Security principal name of the group (e.g., developers@idm.example.com).",
+				Computed:            true,
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the group.",
+				Computed:            true,
+			},
+			"members": schema.SetAttribute{
+				MarkdownDescription: "Direct members of the group, as reported by Kanidm.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"gidnumber": schema.Int64Attribute{
+				MarkdownDescription: "POSIX group ID. Null if the group is not a POSIX group.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *groupDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *client.Client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	d.client = c
+}
+
+func (d *groupDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config groupDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading group data source", map[string]any{
+		"id": config.ID.ValueString(),
+	})
+
+	group, err := d.client.GetGroup(ctx, config.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("id"),
+				"Group Not Found",
+				"No group named "+config.ID.ValueString()+" exists.",
+			)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Reading Group",
+			"Could not read group: "+err.Error(),
+		)
+		return
+	}
+
+	state := groupDataSourceModel{
+		ID:          config.ID,
+		UUID:        types.StringValue(group.UUID),
+		SPN:         types.StringValue(group.SPN),
+		Description: types.StringValue(group.Description),
+		GIDNumber:   types.Int64Null(),
+	}
+
+	if group.GIDNumber != "" {
+		gid, err := strconv.ParseInt(group.GIDNumber, 10, 64)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Invalid GID Number",
+				"Kanidm returned a gidnumber that is not an integer: "+group.GIDNumber,
+			)
+			return
+		}
+		state.GIDNumber = types.Int64Value(gid)
+	}
+
+	membersSet, diags := types.SetValueFrom(ctx, types.StringType, group.Members)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Members = membersSet
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,214 @@
+package provider
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ssoriche/terraform-provider-kanidm/internal/client"
+)
+
+var _ datasource.DataSourceWithConfigure = (*groupEffectiveMembersDataSource)(nil)
+
+func NewGroupEffectiveMembersDataSource() datasource.DataSource {
+	return &groupEffectiveMembersDataSource{}
+}
+
+type groupEffectiveMembersDataSource struct {
+	client *client.Client
+}
+
+type groupEffectiveMembersDataSourceModel struct {
+	Group       types.String           `tfsdk:"group"`
+	UUID        types.String           `tfsdk:"uuid"`
+	MemberNames types.Set              `tfsdk:"member_names"`
+	Members     []effectiveMemberModel `tfsdk:"members"`
+	Groups      []effectiveMemberModel `tfsdk:"groups"`
+}
+
+type effectiveMemberModel struct {
+	Name types.String `tfsdk:"name"`
+	UUID types.String `tfsdk:"uuid"`
+	SPN  types.String `tfsdk:"spn"`
+	Kind types.String `tfsdk:"kind"`
+	Path types.List   `tfsdk:"path"`
+}
+
+func (d *groupEffectiveMembersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_group_effective_members"
+}
+
+func (d *groupEffectiveMembersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	memberAttributes := func(kindDescription string) schema.NestedAttributeObject {
+		return schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{
+					MarkdownDescription: "Name of the entry.",
+					Computed:            true,
+				},
+				"uuid": schema.StringAttribute{
+					MarkdownDescription: "Immutable UUID of the entry.",
+					Computed:            true,
+				},
+				"spn": schema.StringAttribute{
+					MarkdownDescription: "Security principal name of the entry.",
+					Computed:            true,
+				},
+				"kind": schema.StringAttribute{
+					MarkdownDescription: kindDescription,
+					Computed:            true,
+				},
+				"path": schema.ListAttribute{
+					MarkdownDescription: "Group names from the queried group down to the group that holds the entry " +
+						"as a direct member. A single element means the entry is a direct member.",
+					Computed:    true,
+					ElementType: types.StringType,
+				},
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Expands the members of a Kanidm group, including members inherited through nested groups.
+
+Nested groups are followed breadth first and each group is expanded once, so membership
+cycles are safe. Each account is reported with the shortest path of groups that grants it
+membership. Entries the provider token cannot read are omitted.
+
+## Example Usage
+
+` + "```hcl" + `
+data "kanidm_group_effective_members" "app" {
+  group = "app-users"
+}
+
+output "app_users" {
+  value = data.kanidm_group_effective_members.app.member_names
+}
+` + "```",
+
+		Attributes: map[string]schema.Attribute{
+			"group": schema.StringAttribute{
+				MarkdownDescription: "Name, SPN or UUID of the group to expand.",
+				Required:            true,
+			},
+			"uuid": schema.StringAttribute{
+				MarkdownDescription: "Immutable UUID of the group.",
+				Computed:            true,
+			},
+			"member_names": schema.SetAttribute{
+				MarkdownDescription: "Names of every person and service account that is an effective member.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"members": schema.ListNestedAttribute{
+				MarkdownDescription: "Persons and service accounts that are effective members, ordered by SPN.",
+				Computed:            true,
+				NestedObject:        memberAttributes("Kind of the entry: `person` or `service_account`."),
+			},
+			"groups": schema.ListNestedAttribute{
+				MarkdownDescription: "Nested groups that were expanded, ordered by SPN.",
+				Computed:            true,
+				NestedObject:        memberAttributes("Kind of the entry, always `group`."),
+			},
+		},
+	}
+}
+
+func (d *groupEffectiveMembersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *client.Client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	d.client = c
+}
+
+func (d *groupEffectiveMembersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state groupEffectiveMembersDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Expanding group members", map[string]any{
+		"group": state.Group.ValueString(),
+	})
+
+	membership, err := d.client.GetEffectiveMembers(ctx, state.Group.ValueString())
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("group"),
+				"Group Not Found",
+				"No group named "+state.Group.ValueString()+" exists.",
+			)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Reading Group Members",
+			"Could not expand group members: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Expanded group members", map[string]any{
+		"group":   membership.Group.ID,
+		"members": len(membership.Members),
+		"groups":  len(membership.Groups),
+	})
+
+	state.UUID = types.StringValue(membership.Group.UUID)
+
+	names := make([]string, 0, len(membership.Members))
+	for _, member := range membership.Members {
+		names = append(names, member.Name)
+	}
+
+	nameSet, diags := types.SetValueFrom(ctx, types.StringType, names)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.MemberNames = nameSet
+
+	state.Members = effectiveMemberModels(ctx, membership.Members, &resp.Diagnostics)
+	state.Groups = effectiveMemberModels(ctx, membership.Groups, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// effectiveMemberModels converts expanded members to their Terraform models
+func effectiveMemberModels(ctx context.Context, members []*client.EffectiveMember, diags *diag.Diagnostics) []effectiveMemberModel {
+	models := make([]effectiveMemberModel, 0, len(members))
+	for _, member := range members {
+		pathList, d := types.ListValueFrom(ctx, types.StringType, member.Path)
+		diags.Append(d...)
+
+		models = append(models, effectiveMemberModel{
+			Name: types.StringValue(member.Name),
+			UUID: types.StringValue(member.UUID),
+			SPN:  types.StringValue(member.SPN),
+			Kind: types.StringValue(string(member.Kind)),
+			Path: pathList,
+		})
+	}
+	return models
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -17,6 +18,8 @@ import (
 var (
 	_ resource.Resource                = (*groupResource)(nil)
 	_ resource.ResourceWithImportState = (*groupResource)(nil)
+	_ resource.ResourceWithIdentity    = (*groupResource)(nil)
+	_ resource.ResourceWithModifyPlan  = (*groupResource)(nil)
 )
 
 func NewGroupResource() resource.Resource {
@@ -28,9 +31,11 @@ type groupResource struct {
 }
 
 type groupResourceModel struct {
-	ID          types.String `tfsdk:"id"`
-	Description types.String `tfsdk:"description"`
-	Members     types.Set    `tfsdk:"members"`
+	ID                 types.String `tfsdk:"id"`
+	UUID               types.String `tfsdk:"uuid"`
+	Description        types.String `tfsdk:"description"`
+	Members            types.Set    `tfsdk:"members"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
 }
 
 func (r *groupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -60,10 +65,15 @@ resource "kanidm_group" "developers" {
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				MarkdownDescription: "Unique identifier for the group (group name). Cannot be changed after creation.",
-				Required:            true,
+				MarkdownDescription: "Unique identifier for the group (group name). " +
+					"Changing this renames the group in place; it keeps its UUID and memberships.",
+				Required: true,
+			},
+			"uuid": schema.StringAttribute{
+				MarkdownDescription: "Immutable UUID of the group, used to track the group across renames.",
+				Computed:            true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
 			"description": schema.StringAttribute{
@@ -71,15 +81,22 @@ resource "kanidm_group" "developers" {
 				Optional:            true,
 			},
 			"members": schema.SetAttribute{
-				MarkdownDescription: "Set of member IDs (persons or service accounts). " +
+				MarkdownDescription: "Set of members (persons, service accounts or nested groups). " +
+					"Each member may be given as a name, SPN or UUID; the form written in configuration " +
+					"is kept in state as long as it refers to the same entry. " +
 					"Members are managed as a complete set - any changes will replace all members.",
 				Optional:    true,
 				ElementType: types.StringType,
 			},
+			"deletion_protection": deletionProtectionAttribute(),
 		},
 	}
 }
 
+func (r *groupResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = entryIdentitySchema()
+}
+
 func (r *groupResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -97,6 +114,10 @@ func (r *groupResource) Configure(_ context.Context, req resource.ConfigureReque
 	r.client = c
 }
 
+func (r *groupResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPlanProtection(ctx, r.client, req, resp, entryNamePaths)
+}
+
 func (r *groupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan groupResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -114,12 +135,12 @@ func (r *groupResource) Create(ctx context.Context, req resource.CreateRequest,
 		description = plan.Description.ValueString()
 	}
 
-	group, err := r.client.CreateGroup(ctx, plan.ID.ValueString(), description)
+	group, err := r.createGroup(ctx, plan.ID.ValueString(), description, &resp.Diagnostics)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Creating Group",
-			"Could not create group: "+err.Error(),
-		)
+		addAPIError(&resp.Diagnostics, "Error Creating Group", "Could not create group: ", err, map[string]path.Path{
+			client.ErrCodeAttributeUniqueness: path.Root("id"),
+			client.ErrCodeValueDenyName:       path.Root("id"),
+		})
 		return
 	}
 
@@ -136,10 +157,9 @@ func (r *groupResource) Create(ctx context.Context, req resource.CreateRequest,
 				"count": len(memberIDs),
 			})
 			if err := r.client.UpdateGroup(ctx, group.ID, "", memberIDs); err != nil {
-				resp.Diagnostics.AddError(
-					"Error Adding Members",
-					"Group was created but members could not be added: "+err.Error(),
-				)
+				addAPIError(&resp.Diagnostics, "Error Adding Members", "Group was created but members could not be added: ", err, map[string]path.Path{
+					client.ErrCodeReferenceLoop: path.Root("members"),
+				})
 				return
 			}
 		}
@@ -157,21 +177,50 @@ func (r *groupResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	// Map response to state
 	plan.ID = types.StringValue(createdGroup.ID)
-	plan.Description = types.StringValue(createdGroup.Description)
+	plan.UUID = types.StringValue(createdGroup.UUID)
+	plan.Description = optionalString(createdGroup.Description, plan.Description)
 
-	// Always set members as a set (empty if no members)
-	membersSet, diags := types.SetValueFrom(ctx, types.StringType, createdGroup.Members)
-	resp.Diagnostics.Append(diags...)
+	// Set members in the form configured (null only when unset and empty)
+	plan.Members = r.membersValue(ctx, createdGroup.Members, plan.Members, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	plan.Members = membersSet
 
 	tflog.Debug(ctx, "Group created successfully", map[string]any{
 		"id": plan.ID.ValueString(),
 	})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	setEntryIdentity(ctx, resp.Identity, plan.UUID, &resp.Diagnostics)
+}
+
+// createGroup creates the group, or revives a recycled group with the same
+// name when the provider deletion_mode is "recycle". A revived group keeps its
+// UUID and the groups it belongs to; its members and description are reset so
+// only the configured ones are set.
+func (r *groupResource) createGroup(ctx context.Context, name, description string, diags *diag.Diagnostics) (*client.Group, error) {
+	revived, err := reviveRecycled(ctx, r.client, client.EntryKindGroup, name, diags)
+	if err != nil {
+		return nil, err
+	}
+	if !revived {
+		return r.client.CreateGroup(ctx, name, description)
+	}
+
+	tflog.Info(ctx, "Revived group from the recycle bin", map[string]any{
+		"id": name,
+	})
+
+	if err := r.client.UpdateGroup(ctx, name, description, []string{}); err != nil {
+		return nil, err
+	}
+	if description == "" {
+		if err := r.client.PurgeEntryAttribute(ctx, client.EntryKindGroup, name, "description"); err != nil {
+			return nil, err
+		}
+	}
+
+	return &client.Group{ID: name}, nil
 }
 
 func (r *groupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -185,8 +234,8 @@ func (r *groupResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		"id": state.ID.ValueString(),
 	})
 
-	// Get current group from API
-	group, err := r.client.GetGroup(ctx, state.ID.ValueString())
+	// Get current group from API, by UUID once known so renames are followed
+	group, err := r.client.GetGroup(ctx, entryRef(state.UUID, state.ID))
 	if err != nil {
 		if errors.Is(err, client.ErrNotFound) {
 			tflog.Warn(ctx, "Group not found, removing from state", map[string]any{
@@ -205,17 +254,19 @@ func (r *groupResource) Read(ctx context.Context, req resource.ReadRequest, resp
 
 	// Update state with current values
 	state.ID = types.StringValue(group.ID)
-	state.Description = types.StringValue(group.Description)
+	state.UUID = types.StringValue(group.UUID)
+	state.Description = optionalString(group.Description, state.Description)
 
-	// Always set members as a set (empty if no members)
-	membersSet, diags := types.SetValueFrom(ctx, types.StringType, group.Members)
-	resp.Diagnostics.Append(diags...)
+	// Set members in the form configured (null only when unset and empty)
+	state.Members = r.membersValue(ctx, group.Members, state.Members, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	state.Members = membersSet
+
+	state.DeletionProtection = deletionProtectionValue(state.DeletionProtection)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	setEntryIdentity(ctx, resp.Identity, state.UUID, &resp.Diagnostics)
 }
 
 func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -231,6 +282,23 @@ func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest,
 		"id": plan.ID.ValueString(),
 	})
 
+	ref := entryRef(state.UUID, state.ID)
+
+	// Rename in place if the group name changed
+	if !plan.ID.Equal(state.ID) {
+		tflog.Debug(ctx, "Renaming group", map[string]any{
+			"from": state.ID.ValueString(),
+			"to":   plan.ID.ValueString(),
+		})
+		if err := r.client.RenameEntry(ctx, client.EntryKindGroup, ref, plan.ID.ValueString()); err != nil {
+			addAPIError(&resp.Diagnostics, "Error Renaming Group", "Could not rename group: ", err, map[string]path.Path{
+				client.ErrCodeAttributeUniqueness: path.Root("id"),
+				client.ErrCodeValueDenyName:       path.Root("id"),
+			})
+			return
+		}
+	}
+
 	// Prepare members list
 	var memberIDs []string
 	if !plan.Members.IsNull() && !plan.Members.IsUnknown() {
@@ -246,16 +314,15 @@ func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest,
 		description = plan.Description.ValueString()
 	}
 
-	if err := r.client.UpdateGroup(ctx, plan.ID.ValueString(), description, memberIDs); err != nil {
-		resp.Diagnostics.AddError(
-			"Error Updating Group",
-			"Could not update group: "+err.Error(),
-		)
+	if err := r.client.UpdateGroup(ctx, ref, description, memberIDs); err != nil {
+		addAPIError(&resp.Diagnostics, "Error Updating Group", "Could not update group: ", err, map[string]path.Path{
+			client.ErrCodeReferenceLoop: path.Root("members"),
+		})
 		return
 	}
 
 	// Read back the updated group
-	updatedGroup, err := r.client.GetGroup(ctx, plan.ID.ValueString())
+	updatedGroup, err := r.client.GetGroup(ctx, ref)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Group",
@@ -266,21 +333,21 @@ func (r *groupResource) Update(ctx context.Context, req resource.UpdateRequest,
 
 	// Update state
 	plan.ID = types.StringValue(updatedGroup.ID)
-	plan.Description = types.StringValue(updatedGroup.Description)
+	plan.UUID = types.StringValue(updatedGroup.UUID)
+	plan.Description = optionalString(updatedGroup.Description, plan.Description)
 
-	// Always set members as a set (empty if no members)
-	membersSet, diags := types.SetValueFrom(ctx, types.StringType, updatedGroup.Members)
-	resp.Diagnostics.Append(diags...)
+	// Set members in the form configured (null only when unset and empty)
+	plan.Members = r.membersValue(ctx, updatedGroup.Members, plan.Members, &resp.Diagnostics)
 	if resp.Diagnostics.HasError() {
 		return
 	}
-	plan.Members = membersSet
 
 	tflog.Debug(ctx, "Group updated successfully", map[string]any{
 		"id": plan.ID.ValueString(),
 	})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	setEntryIdentity(ctx, resp.Identity, plan.UUID, &resp.Diagnostics)
 }
 
 func (r *groupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -294,8 +361,13 @@ func (r *groupResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		"id": state.ID.ValueString(),
 	})
 
+	checkDeletionProtection(ctx, r.client, req.State, entryNamePaths, "destroyed", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Delete the group
-	if err := r.client.DeleteGroup(ctx, state.ID.ValueString()); err != nil {
+	if err := r.client.DeleteGroup(ctx, entryRef(state.UUID, state.ID)); err != nil {
 		if errors.Is(err, client.ErrNotFound) {
 			tflog.Warn(ctx, "Group not found during delete, removing from state", map[string]any{
 				"id": state.ID.ValueString(),
@@ -316,10 +388,57 @@ func (r *groupResource) Delete(ctx context.Context, req resource.DeleteRequest,
 }
 
 func (r *groupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Use the ID (group name) directly as the import identifier
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// Accept the group name, the UUID or an identity with the UUID; Read
+	// resolves each of them and replaces the id with the current group name
+	importEntryState(ctx, path.Root("id"), req, resp)
 
 	tflog.Debug(ctx, "Imported group", map[string]any{
 		"id": req.ID,
 	})
 }
+
+// membersValue converts the members reported by Kanidm, which are usually
+// SPNs, into a set that uses the identifiers from configured wherever they
+// refer to the same entry. When members are not configured, e.g. after
+// import, they are reported by name and an empty membership stays null.
+func (r *groupResource) membersValue(ctx context.Context, actual []string, configured types.Set, diags *diag.Diagnostics) types.Set {
+	var members []string
+	if configured.IsNull() {
+		if len(actual) == 0 {
+			return types.SetNull(types.StringType)
+		}
+
+		forms, err := r.client.MemberForms(ctx, actual, nil, true)
+		if err != nil {
+			diags.AddError(
+				"Error Resolving Group Members",
+				"Could not resolve group members: "+err.Error(),
+			)
+			return types.SetNull(types.StringType)
+		}
+
+		for _, id := range actual {
+			members = append(members, forms[id])
+		}
+	} else {
+		var preferred []string
+		elementsAs(ctx, configured, &preferred, diags)
+		if diags.HasError() {
+			return types.SetNull(types.StringType)
+		}
+
+		var err error
+		members, err = r.client.NormalizeMembers(ctx, actual, preferred)
+		if err != nil {
+			diags.AddError(
+				"Error Resolving Group Members",
+				"Could not resolve group members: "+err.Error(),
+			)
+			return types.SetNull(types.StringType)
+		}
+	}
+
+	membersSet, d := types.SetValueFrom(ctx, types.StringType, members)
+	diags.Append(d...)
+	return membersSet
+}
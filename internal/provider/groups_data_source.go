@@ -0,0 +1,175 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ssoriche/terraform-provider-kanidm/internal/client"
+)
+
+var _ datasource.DataSourceWithConfigure = (*groupsDataSource)(nil)
+
+func NewGroupsDataSource() datasource.DataSource {
+	return &groupsDataSource{}
+}
+
+type groupsDataSource struct {
+	client *client.Client
+}
+
+type groupsDataSourceModel struct {
+	NamePrefix types.String        `tfsdk:"name_prefix"`
+	MemberOf   types.String        `tfsdk:"member_of"`
+	Class      types.String        `tfsdk:"class"`
+	Filter     types.String        `tfsdk:"filter"`
+	IDs        types.List          `tfsdk:"ids"`
+	Groups     []groupSummaryModel `tfsdk:"groups"`
+}
+
+type groupSummaryModel struct {
+	ID          types.String `tfsdk:"id"`
+	UUID        types.String `tfsdk:"uuid"`
+	SPN         types.String `tfsdk:"spn"`
+	Description types.String `tfsdk:"description"`
+	Members     types.Set    `tfsdk:"members"`
+}
+
+func (d *groupsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_groups"
+}
+
+func (d *groupsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attrs := searchFilterAttributes(true)
+	attrs["ids"] = schema.ListAttribute{
+		MarkdownDescription: "Names of the matching groups, in the order returned by Kanidm.",
+		Computed:            true,
+		ElementType:         types.StringType,
+	}
+	attrs["groups"] = schema.ListNestedAttribute{
+		MarkdownDescription: "The matching groups.",
+		Computed:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"id": schema.StringAttribute{
+					MarkdownDescription: "Name of the group.",
+					Computed:            true,
+				},
+				"uuid": schema.StringAttribute{
+					MarkdownDescription: "Immutable UUID of the group.",
+					Computed:            true,
+				},
+				"spn": schema.StringAttribute{
+					MarkdownDescription: "Security principal name of the group.",
+					Computed:            true,
+				},
+				"description": schema.StringAttribute{
+					MarkdownDescription: "Description of the group.",
+					Computed:            true,
+				},
+				"members": schema.SetAttribute{
+					MarkdownDescription: "Direct members of the group, as reported by Kanidm.",
+					Computed:            true,
+					ElementType:         types.StringType,
+				},
+			},
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Lists Kanidm groups, optionally filtered.
+
+## Example Usage
+
+` + "```hcl" + `
+data "kanidm_groups" "teams" {
+  name_prefix = "team-"
+}
+` + "```",
+		Attributes: attrs,
+	}
+}
+
+func (d *groupsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *client.Client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	d.client = c
+}
+
+func (d *groupsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state groupsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter, diags := buildSearchFilter(state.MemberOf, state.Class, state.Filter)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Listing groups", map[string]any{
+		"filter": filter,
+	})
+
+	var groups []*client.Group
+	var err error
+	if filter == nil {
+		groups, err = d.client.ListGroups(ctx)
+	} else {
+		groups, err = d.client.SearchGroups(ctx, filter)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing Groups",
+			"Could not list groups: "+err.Error(),
+		)
+		return
+	}
+
+	ids := make([]string, 0, len(groups))
+	state.Groups = make([]groupSummaryModel, 0, len(groups))
+	for _, group := range groups {
+		if !matchesNamePrefix(group.ID, state.NamePrefix) {
+			continue
+		}
+
+		membersSet, diags := types.SetValueFrom(ctx, types.StringType, group.Members)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		ids = append(ids, group.ID)
+		state.Groups = append(state.Groups, groupSummaryModel{
+			ID:          types.StringValue(group.ID),
+			UUID:        types.StringValue(group.UUID),
+			SPN:         types.StringValue(group.SPN),
+			Description: types.StringValue(group.Description),
+			Members:     membersSet,
+		})
+	}
+
+	idList, diags := types.ListValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.IDs = idList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ssoriche/terraform-provider-kanidm/internal/client"
+)
+
+// entryRef returns the identifier used to address an entry in API calls.
+// The immutable UUID is preferred so lookups survive renames; the name is
+// used until the UUID is known, e.g. straight after import.
+func entryRef(uuid, name types.String) string {
+	if !uuid.IsNull() && !uuid.IsUnknown() && uuid.ValueString() != "" {
+		return uuid.ValueString()
+	}
+	return name.ValueString()
+}
+
+// optionalString converts a value read from Kanidm for an optional
+// attribute. An empty value stays null when prior is null, so an attribute
+// left out of configuration is not reported as changing to "".
+func optionalString(value string, prior types.String) types.String {
+	if value == "" && prior.IsNull() {
+		return types.StringNull()
+	}
+	return types.StringValue(value)
+}
+
+// orderLike returns values ordered as they appear in prior, followed by any
+// values prior does not contain. Kanidm returns multi-value attributes
+// sorted, so this keeps list attributes in the order they were configured.
+func orderLike(values, prior []string) []string {
+	remaining := make(map[string]int, len(values))
+	for _, v := range values {
+		remaining[v]++
+	}
+
+	ordered := make([]string, 0, len(values))
+	for _, v := range prior {
+		if remaining[v] > 0 {
+			ordered = append(ordered, v)
+			remaining[v]--
+		}
+	}
+
+	for _, v := range values {
+		if remaining[v] > 0 {
+			ordered = append(ordered, v)
+			remaining[v]--
+		}
+	}
+
+	return ordered
+}
+
+// elementsValue is implemented by list and set values
+type elementsValue interface {
+	IsNull() bool
+	IsUnknown() bool
+	ElementsAs(ctx context.Context, target any, allowUnhandled bool) diag.Diagnostics
+}
+
+// elementsAs decodes the elements of a list or set into target, leaving
+// target untouched when the value is null or unknown
+func elementsAs(ctx context.Context, value elementsValue, target any, diags *diag.Diagnostics) {
+	if value.IsNull() || value.IsUnknown() {
+		return
+	}
+	diags.Append(value.ElementsAs(ctx, target, false)...)
+}
+
+// reviveRecycled revives a recycled entry named name when the deletion mode
+// allows it. When several recycled entries share the name it warns and
+// reports that nothing was revived, so the caller creates a new entry.
+func reviveRecycled(ctx context.Context, c *client.Client, kind client.EntryKind, name string, diags *diag.Diagnostics) (bool, error) {
+	revived, err := c.ReviveRecycled(ctx, kind, name)
+	if errors.Is(err, client.ErrAmbiguousRecycled) {
+		diags.AddWarning(
+			"Recycled Entry Not Revived",
+			"Several recycled "+string(kind)+" entries are named "+name+", so none was revived and a new entry "+
+				"was created instead. Revive the intended entry by hand and import it to keep its UUID.",
+		)
+		return false, nil
+	}
+	return revived, err
+}
@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// entryIdentityModel is the resource identity of resources that manage a
+// Kanidm entry. The UUID is used because it survives renames.
+type entryIdentityModel struct {
+	UUID types.String `tfsdk:"uuid"`
+}
+
+// entryIdentitySchema returns the identity schema shared by entry resources
+func entryIdentitySchema() identityschema.Schema {
+	return identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"uuid": identityschema.StringAttribute{
+				Description:       "Immutable UUID of the Kanidm entry.",
+				RequiredForImport: true,
+			},
+		},
+	}
+}
+
+// setEntryIdentity records the entry UUID as the resource identity. The
+// identity is nil when Terraform does not support resource identities.
+func setEntryIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity, uuid types.String, diags *diag.Diagnostics) {
+	if identity == nil {
+		return
+	}
+	diags.Append(identity.Set(ctx, entryIdentityModel{UUID: uuid})...)
+}
+
+// importEntryState imports an entry by name, UUID or resource identity into
+// the attribute at idPath. Read resolves whichever was given.
+func importEntryState(ctx context.Context, idPath path.Path, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughWithIdentity(ctx, idPath, path.Root("uuid"), req, resp)
+}
@@ -0,0 +1,383 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ssoriche/terraform-provider-kanidm/internal/client"
+)
+
+// Ensure the implementation satisfies the required interfaces
+var (
+	_ resource.Resource                   = (*keyObjectRotationResource)(nil)
+	_ resource.ResourceWithValidateConfig = (*keyObjectRotationResource)(nil)
+	_ resource.ResourceWithModifyPlan     = (*keyObjectRotationResource)(nil)
+)
+
+// NewKeyObjectRotationResource creates a new key object rotation resource
+func NewKeyObjectRotationResource() resource.Resource {
+	return &keyObjectRotationResource{}
+}
+
+// keyObjectRotationResource is the resource implementation
+type keyObjectRotationResource struct {
+	client *client.Client
+}
+
+// keyObjectRotationResourceModel describes the resource data model
+type keyObjectRotationResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	OAuth2Client       types.String `tfsdk:"oauth2_client"`
+	RotateAt           types.String `tfsdk:"rotate_at"`
+	Triggers           types.Map    `tfsdk:"triggers"`
+	RevokeKeyIDs       types.Set    `tfsdk:"revoke_key_ids"`
+	KeyIDs             types.List   `tfsdk:"key_ids"`
+	JWKS               types.String `tfsdk:"jwks"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
+}
+
+// Metadata returns the resource type name
+func (r *keyObjectRotationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_key_object_rotation"
+}
+
+// Schema defines the schema for the resource
+func (r *keyObjectRotationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Rotates the signing keys of a Kanidm key object and revokes compromised keys.
+
+Kanidm signs OIDC tokens with a key object per OAuth2 client, and other tokens with the domain key
+object. Creating this resource schedules a new key to become active at ` + "`rotate_at`" + `; previous keys
+remain valid for verifying existing tokens until they are revoked. Change ` + "`triggers`" + ` or
+` + "`rotate_at`" + ` to rotate again. Destroying the resource does not undo a rotation.
+
+## Example Usage
+
+` + "```hcl" + `
+resource "kanidm_key_object_rotation" "grafana" {
+  oauth2_client = kanidm_oauth2_basic.grafana.name
+
+  triggers = {
+    drill = "2026-q4"
+  }
+
+  revoke_key_ids = ["0f3c...e1"]
+}
+
+output "grafana_jwks" {
+  value = kanidm_key_object_rotation.grafana.jwks
+}
+` + "```",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Key object and rotation time, e.g. `oauth2/grafana@2026-10-18T10:00:00Z`.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"oauth2_client": schema.StringAttribute{
+				MarkdownDescription: "Name of the OAuth2 client whose keys are rotated. The domain key object is rotated when omitted.",
+				Optional:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"rotate_at": schema.StringAttribute{
+				MarkdownDescription: "RFC 3339 time at which the new key becomes active. Defaults to the time of apply. " +
+					"A future time lets relying parties fetch the new key before it is used.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				MarkdownDescription: "Arbitrary values that cause a new rotation when changed.",
+				Optional:            true,
+				ElementType:         types.StringType,
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"revoke_key_ids": schema.SetAttribute{
+				MarkdownDescription: "IDs of keys to revoke. Tokens signed with a revoked key are rejected. " +
+					"Revocation cannot be undone, so removing an ID from the set has no effect.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"key_ids": schema.ListAttribute{
+				MarkdownDescription: "IDs of the keys currently published for the OAuth2 client. " +
+					"Null for the domain, which has no published key set.",
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"jwks": schema.StringAttribute{
+				MarkdownDescription: "JSON Web Key Set of the OAuth2 client, as served to relying parties. Null for the domain.",
+				Computed:            true,
+			},
+			"deletion_protection": deletionProtectionAttribute(),
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource
+func (r *keyObjectRotationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			"Expected *client.Client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	r.client = c
+}
+
+// ValidateConfig checks that rotate_at is a valid time
+func (r *keyObjectRotationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var rotateAt types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("rotate_at"), &rotateAt)...)
+	if resp.Diagnostics.HasError() || rotateAt.IsNull() || rotateAt.IsUnknown() {
+		return
+	}
+
+	if _, err := time.Parse(time.RFC3339, rotateAt.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("rotate_at"),
+			"Invalid Rotation Time",
+			"rotate_at must be an RFC 3339 time, e.g. 2026-10-18T10:00:00Z: "+err.Error(),
+		)
+	}
+}
+
+// ModifyPlan refuses to plan the destruction or replacement of a protected rotation
+func (r *keyObjectRotationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPlanProtection(ctx, r.client, req, resp, nil, path.Root("oauth2_client"), path.Root("rotate_at"), path.Root("triggers"))
+}
+
+// Create rotates the key object and revokes the listed keys
+func (r *keyObjectRotationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan keyObjectRotationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	at := time.Now().UTC().Truncate(time.Second)
+	if !plan.RotateAt.IsNull() && !plan.RotateAt.IsUnknown() {
+		// Validated in ValidateConfig
+		at, _ = time.Parse(time.RFC3339, plan.RotateAt.ValueString())
+	}
+
+	tflog.Debug(ctx, "Rotating key object", map[string]any{
+		"key_object": r.keyObject(&plan),
+		"rotate_at":  at.Format(time.RFC3339),
+	})
+
+	var err error
+	if plan.OAuth2Client.IsNull() {
+		err = r.client.RotateDomainKeys(ctx, at)
+	} else {
+		err = r.client.RotateOAuth2Keys(ctx, plan.OAuth2Client.ValueString(), at)
+	}
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error Rotating Keys", "Could not rotate keys of "+r.keyObject(&plan)+": ", err, map[string]path.Path{
+			client.ErrCodeNoMatchingEntries: path.Root("oauth2_client"),
+			client.ErrCodeInvalidAttribute:  path.Root("rotate_at"),
+		})
+		return
+	}
+
+	if plan.RotateAt.IsNull() || plan.RotateAt.IsUnknown() {
+		plan.RotateAt = types.StringValue(at.Format(time.RFC3339))
+	}
+	plan.ID = types.StringValue(r.keyObject(&plan) + "@" + plan.RotateAt.ValueString())
+
+	// A failed revocation still saves the rotation and the keys revoked
+	// before it, so they are not repeated
+	if !r.revoke(ctx, &plan, types.SetNull(types.StringType), &resp.Diagnostics) {
+		return
+	}
+
+	if _, ok := r.refresh(ctx, &plan, &resp.Diagnostics); !ok {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the published keys
+func (r *keyObjectRotationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state keyObjectRotationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading key object", map[string]any{
+		"key_object": r.keyObject(&state),
+	})
+
+	found, ok := r.refresh(ctx, &state, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+	if !found {
+		tflog.Warn(ctx, "OAuth2 client not found, removing key rotation from state", map[string]any{
+			"oauth2_client": state.OAuth2Client.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	state.DeletionProtection = deletionProtectionValue(state.DeletionProtection)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update revokes keys added to revoke_key_ids
+func (r *keyObjectRotationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state keyObjectRotationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Updating key object rotation", map[string]any{
+		"key_object": r.keyObject(&plan),
+	})
+
+	// A failed revocation still saves the keys revoked before it, so the
+	// next apply only retries the rest
+	if !r.revoke(ctx, &plan, state.RevokeKeyIDs, &resp.Diagnostics) {
+		return
+	}
+
+	if _, ok := r.refresh(ctx, &plan, &resp.Diagnostics); !ok {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete removes the rotation from state. Rotations and revocations cannot
+// be undone, so nothing is changed in Kanidm.
+func (r *keyObjectRotationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	checkDeletionProtection(ctx, r.client, req.State, nil, "destroyed", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Removing key object rotation from state; Kanidm keys are unchanged")
+}
+
+// keyObject describes the rotated key object for IDs and messages
+func (r *keyObjectRotationResource) keyObject(model *keyObjectRotationResourceModel) string {
+	if model.OAuth2Client.IsNull() {
+		return "domain"
+	}
+	return "oauth2/" + model.OAuth2Client.ValueString()
+}
+
+// revoke revokes the key IDs in plan that are not in prior. When a
+// revocation fails, plan.RevokeKeyIDs is narrowed to the keys that are
+// revoked so state records them. It reports whether plan can be saved.
+func (r *keyObjectRotationResource) revoke(ctx context.Context, plan *keyObjectRotationResourceModel, prior types.Set, diags *diag.Diagnostics) bool {
+	var wanted, revoked []string
+	elementsAs(ctx, plan.RevokeKeyIDs, &wanted, diags)
+	elementsAs(ctx, prior, &revoked, diags)
+	if diags.HasError() {
+		return false
+	}
+
+	done := make(map[string]bool, len(revoked))
+	for _, keyID := range revoked {
+		done[keyID] = true
+	}
+
+	for _, keyID := range wanted {
+		if done[keyID] {
+			continue
+		}
+
+		tflog.Debug(ctx, "Revoking key", map[string]any{
+			"key_object": r.keyObject(plan),
+			"key_id":     keyID,
+		})
+
+		var err error
+		if plan.OAuth2Client.IsNull() {
+			err = r.client.RevokeDomainKey(ctx, keyID)
+		} else {
+			err = r.client.RevokeOAuth2Key(ctx, plan.OAuth2Client.ValueString(), keyID)
+		}
+		if err != nil {
+			addAPIError(diags, "Error Revoking Key", "Could not revoke key "+keyID+": ", err, map[string]path.Path{
+				client.ErrCodeInvalidAttribute: path.Root("revoke_key_ids"),
+			})
+
+			var recorded []string
+			for _, id := range wanted {
+				if done[id] {
+					recorded = append(recorded, id)
+				}
+			}
+			recordedSet, d := types.SetValueFrom(ctx, types.StringType, recorded)
+			diags.Append(d...)
+			plan.RevokeKeyIDs = recordedSet
+			return !d.HasError()
+		}
+		done[keyID] = true
+	}
+
+	return true
+}
+
+// refresh reads the published keys of the key object into model. It reports
+// whether the OAuth2 client still exists and whether the read succeeded.
+func (r *keyObjectRotationResource) refresh(ctx context.Context, model *keyObjectRotationResourceModel, diags *diag.Diagnostics) (bool, bool) {
+	if model.OAuth2Client.IsNull() {
+		model.KeyIDs = types.ListNull(types.StringType)
+		model.JWKS = types.StringNull()
+		return true, true
+	}
+
+	jwks, err := r.client.GetOAuth2JWKS(ctx, model.OAuth2Client.ValueString())
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			return false, true
+		}
+
+		diags.AddError(
+			"Error Reading Keys",
+			"Could not read the published keys of "+r.keyObject(model)+": "+err.Error(),
+		)
+		return false, false
+	}
+
+	keyIDs, d := types.ListValueFrom(ctx, types.StringType, jwks.KeyIDs)
+	diags.Append(d...)
+	model.KeyIDs = keyIDs
+	model.JWKS = types.StringValue(jwks.JSON)
+
+	return true, !d.HasError()
+}
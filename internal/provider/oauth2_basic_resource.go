@@ -3,11 +3,16 @@ package provider
 import (
 	"context"
 	"errors"
+	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -15,10 +20,31 @@ import (
 )
 
 var (
-	_ resource.Resource                = (*oauth2BasicResource)(nil)
-	_ resource.ResourceWithImportState = (*oauth2BasicResource)(nil)
+	_ resource.Resource                   = (*oauth2BasicResource)(nil)
+	_ resource.ResourceWithImportState    = (*oauth2BasicResource)(nil)
+	_ resource.ResourceWithIdentity       = (*oauth2BasicResource)(nil)
+	_ resource.ResourceWithValidateConfig = (*oauth2BasicResource)(nil)
+	_ resource.ResourceWithModifyPlan     = (*oauth2BasicResource)(nil)
 )
 
+// oauth2BasicNamePaths are the state attributes holding the name and UUID
+// of the OAuth2 client, checked against protected_names
+var oauth2BasicNamePaths = []path.Path{path.Root("name"), path.Root("uuid")}
+
+// scopeMapObjectType is the element type of the scope_map and sup_scope_map sets
+var scopeMapObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"group":  types.StringType,
+	"scopes": types.ListType{ElemType: types.StringType},
+}}
+
+// claimMapObjectType is the element type of the claim_map set
+var claimMapObjectType = types.ObjectType{AttrTypes: map[string]attr.Type{
+	"claim":  types.StringType,
+	"group":  types.StringType,
+	"join":   types.StringType,
+	"values": types.ListType{ElemType: types.StringType},
+}}
+
 func NewOAuth2BasicResource() resource.Resource {
 	return &oauth2BasicResource{}
 }
@@ -28,12 +54,20 @@ type oauth2BasicResource struct {
 }
 
 type oauth2BasicResourceModel struct {
-	Name         types.String `tfsdk:"name"`
-	DisplayName  types.String `tfsdk:"displayname"`
-	Origin       types.String `tfsdk:"origin"`
-	RedirectURIs types.List   `tfsdk:"redirect_uris"`
-	ScopeMaps    types.Set    `tfsdk:"scope_map"`
-	ClientSecret types.String `tfsdk:"client_secret"`
+	Name                           types.String `tfsdk:"name"`
+	UUID                           types.String `tfsdk:"uuid"`
+	DisplayName                    types.String `tfsdk:"displayname"`
+	Origin                         types.String `tfsdk:"origin"`
+	RedirectURIs                   types.List   `tfsdk:"redirect_uris"`
+	AllowInsecureClientDisablePKCE types.Bool   `tfsdk:"allow_insecure_client_disable_pkce"`
+	LegacyCrypto                   types.Bool   `tfsdk:"legacy_crypto"`
+	PreferShortUsername            types.Bool   `tfsdk:"prefer_short_username"`
+	StrictRedirectURI              types.Bool   `tfsdk:"strict_redirect_uri"`
+	ScopeMaps                      types.Set    `tfsdk:"scope_map"`
+	SupScopeMaps                   types.Set    `tfsdk:"sup_scope_map"`
+	ClaimMaps                      types.Set    `tfsdk:"claim_map"`
+	ClientSecret                   types.String `tfsdk:"client_secret"`
+	DeletionProtection             types.Bool   `tfsdk:"deletion_protection"`
 }
 
 type scopeMapModel struct {
@@ -41,6 +75,13 @@ type scopeMapModel struct {
 	Scopes types.List   `tfsdk:"scopes"`
 }
 
+type claimMapModel struct {
+	Claim  types.String `tfsdk:"claim"`
+	Group  types.String `tfsdk:"group"`
+	Join   types.String `tfsdk:"join"`
+	Values types.List   `tfsdk:"values"`
+}
+
 func (r *oauth2BasicResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_oauth2_basic"
 }
@@ -73,6 +114,17 @@ resource "kanidm_oauth2_basic" "grafana" {
     group  = "developers"
     scopes = ["openid", "profile", "email"]
   }
+
+  sup_scope_map {
+    group  = "admins"
+    scopes = ["grafana_admin"]
+  }
+
+  claim_map {
+    claim  = "grafana_role"
+    group  = "admins"
+    values = ["Admin"]
+  }
 }
 
 # Store the client secret in 1Password or another secret manager
@@ -83,14 +135,26 @@ output "grafana_client_secret" {
 ` + "```" + `
 
 **Important:** The client secret is only available during creation and cannot be recovered later.
-Store it securely immediately after creation. You can regenerate it using the Kanidm CLI if needed.`,
+Store it securely immediately after creation. You can regenerate it using the Kanidm CLI if needed.
+
+## Import
+
+Existing clients can be imported by name or UUID. Scope maps, supplementary scope maps, claim maps,
+redirect URIs and the boolean settings are all read from Kanidm, so a configuration that matches
+the client produces an empty plan.`,
 
 		Attributes: map[string]schema.Attribute{
 			"name": schema.StringAttribute{
-				MarkdownDescription: "Unique identifier for the OAuth2 client (client ID). Cannot be changed after creation.",
-				Required:            true,
+				MarkdownDescription: "Unique identifier for the OAuth2 client (client ID). " +
+					"Changing this renames the client in place; it keeps its UUID and client secret, " +
+					"but applications must be reconfigured with the new client ID.",
+				Required: true,
+			},
+			"uuid": schema.StringAttribute{
+				MarkdownDescription: "Immutable UUID of the OAuth2 client, used to track the client across renames.",
+				Computed:            true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
 			"displayname": schema.StringAttribute{
@@ -98,20 +162,61 @@ Store it securely immediately after creation. You can regenerate it using the Ka
 				Required:            true,
 			},
 			"origin": schema.StringAttribute{
-				MarkdownDescription: "Origin URL where the OAuth2 client application is hosted (e.g., https://grafana.example.com).",
-				Required:            true,
+				MarkdownDescription: "Origin URL where the OAuth2 client application is hosted (e.g., https://grafana.example.com). " +
+					"Users are sent here from the Kanidm application list.",
+				Required: true,
 			},
 			"redirect_uris": schema.ListAttribute{
-				MarkdownDescription: "List of allowed redirect URIs for OAuth2 callbacks.",
-				Optional:            true,
-				ElementType:         types.StringType,
+				MarkdownDescription: "List of allowed redirect URIs for OAuth2 callbacks. " +
+					"When omitted, the origin is registered as the only redirect URI.",
+				Optional:    true,
+				Computed:    true,
+				ElementType: types.StringType,
+			},
+			"allow_insecure_client_disable_pkce": schema.BoolAttribute{
+				MarkdownDescription: "Allow the client to authenticate without PKCE. Only enable this for " +
+					"applications that cannot support PKCE. When omitted, the current setting is kept.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"legacy_crypto": schema.BoolAttribute{
+				MarkdownDescription: "Sign ID tokens with RS256 for applications that do not support ES256. " +
+					"When omitted, the current setting is kept.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"prefer_short_username": schema.BoolAttribute{
+				MarkdownDescription: "Use the short username rather than the SPN in the preferred_username claim. " +
+					"When omitted, the current setting is kept.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"strict_redirect_uri": schema.BoolAttribute{
+				MarkdownDescription: "Require redirect URIs to match a registered URI exactly rather than only its origin. " +
+					"When omitted, the current setting is kept.",
+				Optional: true,
+				Computed: true,
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.UseStateForUnknown(),
+				},
 			},
 			"client_secret": schema.StringAttribute{
 				MarkdownDescription: "Client secret for the OAuth2 basic client. **Only available during creation.** " +
-					"Store this secret securely as it cannot be retrieved later.",
+					"Store this secret securely as it cannot be retrieved later. It is stored in state; use the " +
+					"`kanidm_oauth2_basic_secret` ephemeral resource to avoid that.",
 				Computed:  true,
 				Sensitive: true,
 			},
+			"deletion_protection": deletionProtectionAttribute(),
 		},
 		Blocks: map[string]schema.Block{
 			"scope_map": schema.SetNestedBlock{
@@ -120,7 +225,7 @@ Store it securely immediately after creation. You can regenerate it using the Ka
 				NestedObject: schema.NestedBlockObject{
 					Attributes: map[string]schema.Attribute{
 						"group": schema.StringAttribute{
-							MarkdownDescription: "Name of the Kanidm group to map scopes to.",
+							MarkdownDescription: "Name, SPN or UUID of the Kanidm group to map scopes to.",
 							Required:            true,
 						},
 						"scopes": schema.ListAttribute{
@@ -131,10 +236,60 @@ Store it securely immediately after creation. You can regenerate it using the Ka
 					},
 				},
 			},
+			"sup_scope_map": schema.SetNestedBlock{
+				MarkdownDescription: "Supplementary scope mappings. Members of the group are granted these scopes " +
+					"in addition to those from scope_map, but they do not grant access on their own.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"group": schema.StringAttribute{
+							MarkdownDescription: "Name, SPN or UUID of the Kanidm group to map scopes to.",
+							Required:            true,
+						},
+						"scopes": schema.ListAttribute{
+							MarkdownDescription: "List of additional OAuth2 scopes to grant to group members.",
+							Required:            true,
+							ElementType:         types.StringType,
+						},
+					},
+				},
+			},
+			"claim_map": schema.SetNestedBlock{
+				MarkdownDescription: "Custom claims issued to members of specific groups. " +
+					"A claim may have one block per group; the values of every matching group are combined.",
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"claim": schema.StringAttribute{
+							MarkdownDescription: "Name of the claim.",
+							Required:            true,
+						},
+						"group": schema.StringAttribute{
+							MarkdownDescription: "Name, SPN or UUID of the Kanidm group whose members receive the values.",
+							Required:            true,
+						},
+						"values": schema.ListAttribute{
+							MarkdownDescription: "Values of the claim for members of the group.",
+							Required:            true,
+							ElementType:         types.StringType,
+						},
+						"join": schema.StringAttribute{
+							MarkdownDescription: "How multiple values are rendered: `array` (JSON array), " +
+								"`csv` (comma separated) or `ssv` (space separated). " +
+								"Must be the same in every block for a claim. Defaults to `array`.",
+							Optional: true,
+							Computed: true,
+							Default:  stringdefault.StaticString(client.OAuth2ClaimJoinArray),
+						},
+					},
+				},
+			},
 		},
 	}
 }
 
+func (r *oauth2BasicResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = entryIdentitySchema()
+}
+
 func (r *oauth2BasicResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -152,6 +307,46 @@ func (r *oauth2BasicResource) Configure(_ context.Context, req resource.Configur
 	r.client = c
 }
 
+func (r *oauth2BasicResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var claimMaps []claimMapModel
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("claim_map"), &claimMaps)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	joins := make(map[string]string)
+	for _, claimMap := range claimMaps {
+		if claimMap.Claim.IsUnknown() || claimMap.Join.IsNull() || claimMap.Join.IsUnknown() {
+			continue
+		}
+
+		claim, join := claimMap.Claim.ValueString(), claimMap.Join.ValueString()
+		switch join {
+		case client.OAuth2ClaimJoinArray, client.OAuth2ClaimJoinCSV, client.OAuth2ClaimJoinSSV:
+		default:
+			resp.Diagnostics.AddAttributeError(
+				path.Root("claim_map"),
+				"Invalid Claim Join",
+				fmt.Sprintf("The join of claim %q must be one of: array, csv, ssv.", claim),
+			)
+			continue
+		}
+
+		if previous, ok := joins[claim]; ok && previous != join {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("claim_map"),
+				"Conflicting Claim Join",
+				fmt.Sprintf("Claim %q uses both %q and %q as its join. Kanidm stores one join per claim.", claim, previous, join),
+			)
+		}
+		joins[claim] = join
+	}
+}
+
+func (r *oauth2BasicResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPlanProtection(ctx, r.client, req, resp, oauth2BasicNamePaths)
+}
+
 func (r *oauth2BasicResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan oauth2BasicResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -171,10 +366,10 @@ func (r *oauth2BasicResource) Create(ctx context.Context, req resource.CreateReq
 		plan.Origin.ValueString(),
 	)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Creating OAuth2 Basic Client",
-			"Could not create OAuth2 basic client: "+err.Error(),
-		)
+		addAPIError(&resp.Diagnostics, "Error Creating OAuth2 Basic Client", "Could not create OAuth2 basic client: ", err, map[string]path.Path{
+			client.ErrCodeAttributeUniqueness: path.Root("name"),
+			client.ErrCodeValueDenyName:       path.Root("name"),
+		})
 		return
 	}
 
@@ -201,34 +396,23 @@ func (r *oauth2BasicResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
-	// Configure scope maps if provided
-	if !plan.ScopeMaps.IsNull() && !plan.ScopeMaps.IsUnknown() {
-		var scopeMaps []scopeMapModel
-		resp.Diagnostics.Append(plan.ScopeMaps.ElementsAs(ctx, &scopeMaps, false)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-
-		for _, scopeMap := range scopeMaps {
-			var scopes []string
-			resp.Diagnostics.Append(scopeMap.Scopes.ElementsAs(ctx, &scopes, false)...)
-			if resp.Diagnostics.HasError() {
-				return
-			}
-
-			tflog.Debug(ctx, "Setting scope map for OAuth2 client", map[string]any{
-				"group":  scopeMap.Group.ValueString(),
-				"scopes": scopes,
-			})
+	if err := r.applyFlags(ctx, oauth2Client.Name, &plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Setting OAuth2 Configuration",
+			"OAuth2 client was created but its settings could not be applied: "+err.Error(),
+		)
+		return
+	}
 
-			if err := r.client.SetOAuth2ScopeMap(ctx, oauth2Client.Name, scopeMap.Group.ValueString(), scopes); err != nil {
-				resp.Diagnostics.AddError(
-					"Error Setting Scope Map",
-					"OAuth2 client was created but scope map could not be configured: "+err.Error(),
-				)
-				return
-			}
-		}
+	// Configure scope maps, supplementary scope maps and claim maps
+	emptyState := oauth2BasicResourceModel{
+		ScopeMaps:    types.SetNull(scopeMapObjectType),
+		SupScopeMaps: types.SetNull(scopeMapObjectType),
+		ClaimMaps:    types.SetNull(claimMapObjectType),
+	}
+	r.syncMaps(ctx, oauth2Client.Name, &emptyState, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// Read back the created OAuth2 client
@@ -242,30 +426,18 @@ func (r *oauth2BasicResource) Create(ctx context.Context, req resource.CreateReq
 	}
 
 	// Map response to state
-	plan.Name = types.StringValue(createdClient.Name)
-	plan.DisplayName = types.StringValue(createdClient.DisplayName)
-	plan.Origin = types.StringValue(createdClient.Origin)
-	plan.ClientSecret = types.StringValue(oauth2Client.ClientSecret)
-
-	if len(createdClient.RedirectURIs) > 0 {
-		redirectURIsList, diags := types.ListValueFrom(ctx, types.StringType, createdClient.RedirectURIs)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		plan.RedirectURIs = redirectURIsList
-	} else {
-		plan.RedirectURIs = types.ListNull(types.StringType)
+	r.setState(ctx, createdClient, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
-
-	// Keep the scope maps from the plan (can't read them back from API in current form)
-	// In a future enhancement, we could parse the scope maps from the API response
+	plan.ClientSecret = types.StringValue(oauth2Client.ClientSecret)
 
 	tflog.Debug(ctx, "OAuth2 basic client created successfully", map[string]any{
 		"name": plan.Name.ValueString(),
 	})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	setEntryIdentity(ctx, resp.Identity, plan.UUID, &resp.Diagnostics)
 }
 
 func (r *oauth2BasicResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -280,7 +452,7 @@ func (r *oauth2BasicResource) Read(ctx context.Context, req resource.ReadRequest
 	})
 
 	// Get current OAuth2 client from API
-	oauth2Client, err := r.client.GetOAuth2Client(ctx, state.Name.ValueString())
+	oauth2Client, err := r.lookup(ctx, state.Name, state.UUID)
 	if err != nil {
 		if errors.Is(err, client.ErrNotFound) {
 			tflog.Warn(ctx, "OAuth2 basic client not found, removing from state", map[string]any{
@@ -308,19 +480,9 @@ func (r *oauth2BasicResource) Read(ctx context.Context, req resource.ReadRequest
 	}
 
 	// Update state with current values
-	state.Name = types.StringValue(oauth2Client.Name)
-	state.DisplayName = types.StringValue(oauth2Client.DisplayName)
-	state.Origin = types.StringValue(oauth2Client.Origin)
-
-	if len(oauth2Client.RedirectURIs) > 0 {
-		redirectURIsList, diags := types.ListValueFrom(ctx, types.StringType, oauth2Client.RedirectURIs)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		state.RedirectURIs = redirectURIsList
-	} else {
-		state.RedirectURIs = types.ListNull(types.StringType)
+	r.setState(ctx, oauth2Client, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// Retrieve client secret if not already in state (e.g., after import)
@@ -343,9 +505,10 @@ func (r *oauth2BasicResource) Read(ctx context.Context, req resource.ReadRequest
 		}
 	}
 
-	// Scope maps preserved from state (can't read them back in current form)
+	state.DeletionProtection = deletionProtectionValue(state.DeletionProtection)
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	setEntryIdentity(ctx, resp.Identity, state.UUID, &resp.Diagnostics)
 }
 
 func (r *oauth2BasicResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -361,6 +524,22 @@ func (r *oauth2BasicResource) Update(ctx context.Context, req resource.UpdateReq
 		"name": plan.Name.ValueString(),
 	})
 
+	// Rename in place if the client name changed. OAuth2 endpoints address
+	// clients by name, so every later call uses the new name.
+	if !plan.Name.Equal(state.Name) {
+		tflog.Debug(ctx, "Renaming OAuth2 basic client", map[string]any{
+			"from": state.Name.ValueString(),
+			"to":   plan.Name.ValueString(),
+		})
+		if err := r.client.RenameEntry(ctx, client.EntryKindOAuth2, state.Name.ValueString(), plan.Name.ValueString()); err != nil {
+			addAPIError(&resp.Diagnostics, "Error Renaming OAuth2 Basic Client", "Could not rename OAuth2 basic client: ", err, map[string]path.Path{
+				client.ErrCodeAttributeUniqueness: path.Root("name"),
+				client.ErrCodeValueDenyName:       path.Root("name"),
+			})
+			return
+		}
+	}
+
 	// Prepare redirect URIs
 	var redirectURIs []string
 	if !plan.RedirectURIs.IsNull() && !plan.RedirectURIs.IsUnknown() {
@@ -385,65 +564,18 @@ func (r *oauth2BasicResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
-	// Handle scope map changes
-	// Get old and new scope maps
-	var oldScopeMaps, newScopeMaps []scopeMapModel
-	resp.Diagnostics.Append(state.ScopeMaps.ElementsAs(ctx, &oldScopeMaps, false)...)
-	resp.Diagnostics.Append(plan.ScopeMaps.ElementsAs(ctx, &newScopeMaps, false)...)
-	if resp.Diagnostics.HasError() {
+	if err := r.applyFlags(ctx, plan.Name.ValueString(), &plan); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating OAuth2 Basic Client",
+			"Could not apply OAuth2 client settings: "+err.Error(),
+		)
 		return
 	}
 
-	// Build maps for easier comparison
-	oldScopeMapsByGroup := make(map[string][]string)
-	for _, sm := range oldScopeMaps {
-		var scopes []string
-		resp.Diagnostics.Append(sm.Scopes.ElementsAs(ctx, &scopes, false)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		oldScopeMapsByGroup[sm.Group.ValueString()] = scopes
-	}
-
-	newScopeMapsByGroup := make(map[string][]string)
-	for _, sm := range newScopeMaps {
-		var scopes []string
-		resp.Diagnostics.Append(sm.Scopes.ElementsAs(ctx, &scopes, false)...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		newScopeMapsByGroup[sm.Group.ValueString()] = scopes
-	}
-
-	// Delete scope maps that are no longer present
-	for group := range oldScopeMapsByGroup {
-		if _, exists := newScopeMapsByGroup[group]; !exists {
-			tflog.Debug(ctx, "Deleting scope map", map[string]any{
-				"group": group,
-			})
-			if err := r.client.DeleteOAuth2ScopeMap(ctx, plan.Name.ValueString(), group); err != nil {
-				resp.Diagnostics.AddError(
-					"Error Deleting Scope Map",
-					"Could not delete scope map: "+err.Error(),
-				)
-				return
-			}
-		}
-	}
-
-	// Add or update scope maps
-	for group, scopes := range newScopeMapsByGroup {
-		tflog.Debug(ctx, "Setting scope map", map[string]any{
-			"group":  group,
-			"scopes": scopes,
-		})
-		if err := r.client.SetOAuth2ScopeMap(ctx, plan.Name.ValueString(), group, scopes); err != nil {
-			resp.Diagnostics.AddError(
-				"Error Setting Scope Map",
-				"Could not set scope map: "+err.Error(),
-			)
-			return
-		}
+	// Handle scope map, supplementary scope map and claim map changes
+	r.syncMaps(ctx, plan.Name.ValueString(), &state, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// Read back the updated OAuth2 client
@@ -457,19 +589,9 @@ func (r *oauth2BasicResource) Update(ctx context.Context, req resource.UpdateReq
 	}
 
 	// Update state
-	plan.Name = types.StringValue(updatedClient.Name)
-	plan.DisplayName = types.StringValue(updatedClient.DisplayName)
-	plan.Origin = types.StringValue(updatedClient.Origin)
-
-	if len(updatedClient.RedirectURIs) > 0 {
-		redirectURIsList, diags := types.ListValueFrom(ctx, types.StringType, updatedClient.RedirectURIs)
-		resp.Diagnostics.Append(diags...)
-		if resp.Diagnostics.HasError() {
-			return
-		}
-		plan.RedirectURIs = redirectURIsList
-	} else {
-		plan.RedirectURIs = types.ListNull(types.StringType)
+	r.setState(ctx, updatedClient, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
 	}
 
 	// Preserve client secret from state (cannot be read back from API)
@@ -480,6 +602,7 @@ func (r *oauth2BasicResource) Update(ctx context.Context, req resource.UpdateReq
 	})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	setEntryIdentity(ctx, resp.Identity, plan.UUID, &resp.Diagnostics)
 }
 
 func (r *oauth2BasicResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -493,6 +616,11 @@ func (r *oauth2BasicResource) Delete(ctx context.Context, req resource.DeleteReq
 		"name": state.Name.ValueString(),
 	})
 
+	checkDeletionProtection(ctx, r.client, req.State, oauth2BasicNamePaths, "destroyed", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Delete the OAuth2 client
 	if err := r.client.DeleteOAuth2Client(ctx, state.Name.ValueString()); err != nil {
 		if errors.Is(err, client.ErrNotFound) {
@@ -514,18 +642,323 @@ func (r *oauth2BasicResource) Delete(ctx context.Context, req resource.DeleteReq
 	})
 }
 
+// applyFlags sets the boolean settings present in the plan. Settings left
+// unknown keep their current value on the server.
+func (r *oauth2BasicResource) applyFlags(ctx context.Context, name string, plan *oauth2BasicResourceModel) error {
+	settings := []struct {
+		value  types.Bool
+		target func(*client.OAuth2Flags) *bool
+	}{
+		{plan.AllowInsecureClientDisablePKCE, func(f *client.OAuth2Flags) *bool { return &f.AllowInsecureClientDisablePKCE }},
+		{plan.LegacyCrypto, func(f *client.OAuth2Flags) *bool { return &f.LegacyCrypto }},
+		{plan.PreferShortUsername, func(f *client.OAuth2Flags) *bool { return &f.PreferShortUsername }},
+		{plan.StrictRedirectURI, func(f *client.OAuth2Flags) *bool { return &f.StrictRedirectURI }},
+	}
+
+	configured := false
+	for _, setting := range settings {
+		if !setting.value.IsNull() && !setting.value.IsUnknown() {
+			configured = true
+		}
+	}
+	if !configured {
+		return nil
+	}
+
+	current, err := r.client.GetOAuth2Client(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	flags := current.Flags
+	for _, setting := range settings {
+		if !setting.value.IsNull() && !setting.value.IsUnknown() {
+			*setting.target(&flags) = setting.value.ValueBool()
+		}
+	}
+
+	tflog.Debug(ctx, "Setting OAuth2 client flags", map[string]any{
+		"name": name,
+	})
+
+	return r.client.UpdateOAuth2Flags(ctx, name, current.Flags, flags)
+}
+
+// syncMaps applies the differences between the scope maps, supplementary
+// scope maps and claim maps of state and plan
+func (r *oauth2BasicResource) syncMaps(ctx context.Context, name string, state, plan *oauth2BasicResourceModel, diags *diag.Diagnostics) {
+	oldScopeMaps := scopeMapsByGroup(ctx, state.ScopeMaps, diags)
+	newScopeMaps := scopeMapsByGroup(ctx, plan.ScopeMaps, diags)
+	oldSupScopeMaps := scopeMapsByGroup(ctx, state.SupScopeMaps, diags)
+	newSupScopeMaps := scopeMapsByGroup(ctx, plan.SupScopeMaps, diags)
+	var oldClaimMaps, newClaimMaps []claimMapModel
+	elementsAs(ctx, state.ClaimMaps, &oldClaimMaps, diags)
+	elementsAs(ctx, plan.ClaimMaps, &newClaimMaps, diags)
+	if diags.HasError() {
+		return
+	}
+
+	// Delete scope maps that are no longer present
+	for group := range oldScopeMaps {
+		if _, exists := newScopeMaps[group]; !exists {
+			tflog.Debug(ctx, "Deleting scope map", map[string]any{
+				"group": group,
+			})
+			if err := r.client.DeleteOAuth2ScopeMap(ctx, name, group); err != nil {
+				addAPIError(diags, "Error Deleting Scope Map", "Could not delete scope map: ", err, nil)
+				return
+			}
+		}
+	}
+
+	// Add or update scope maps
+	for group, scopes := range newScopeMaps {
+		if old, exists := oldScopeMaps[group]; exists && equalStringSets(old, scopes) {
+			continue
+		}
+		tflog.Debug(ctx, "Setting scope map", map[string]any{
+			"group":  group,
+			"scopes": scopes,
+		})
+		if err := r.client.SetOAuth2ScopeMap(ctx, name, group, scopes); err != nil {
+			addAPIError(diags, "Error Setting Scope Map", "Could not set scope map: ", err, nil)
+			return
+		}
+	}
+
+	// Supplementary scope maps follow the same rules
+	for group := range oldSupScopeMaps {
+		if _, exists := newSupScopeMaps[group]; !exists {
+			tflog.Debug(ctx, "Deleting supplementary scope map", map[string]any{
+				"group": group,
+			})
+			if err := r.client.DeleteOAuth2SupScopeMap(ctx, name, group); err != nil {
+				addAPIError(diags, "Error Deleting Supplementary Scope Map", "Could not delete supplementary scope map: ", err, nil)
+				return
+			}
+		}
+	}
+
+	for group, scopes := range newSupScopeMaps {
+		if old, exists := oldSupScopeMaps[group]; exists && equalStringSets(old, scopes) {
+			continue
+		}
+		tflog.Debug(ctx, "Setting supplementary scope map", map[string]any{
+			"group":  group,
+			"scopes": scopes,
+		})
+		if err := r.client.SetOAuth2SupScopeMap(ctx, name, group, scopes); err != nil {
+			addAPIError(diags, "Error Setting Supplementary Scope Map", "Could not set supplementary scope map: ", err, nil)
+			return
+		}
+	}
+
+	// Delete claim maps whose claim and group pair is no longer present
+	planned := make(map[[2]string]bool, len(newClaimMaps))
+	for _, cm := range newClaimMaps {
+		planned[[2]string{cm.Claim.ValueString(), cm.Group.ValueString()}] = true
+	}
+
+	for _, cm := range oldClaimMaps {
+		if planned[[2]string{cm.Claim.ValueString(), cm.Group.ValueString()}] {
+			continue
+		}
+		tflog.Debug(ctx, "Deleting claim map", map[string]any{
+			"claim": cm.Claim.ValueString(),
+			"group": cm.Group.ValueString(),
+		})
+		if err := r.client.DeleteOAuth2ClaimMap(ctx, name, cm.Claim.ValueString(), cm.Group.ValueString()); err != nil {
+			addAPIError(diags, "Error Deleting Claim Map", "Could not delete claim map: ", err, nil)
+			return
+		}
+	}
+
+	// Set the values of every planned claim map, then the join of each claim
+	joins := make(map[string]string)
+	for _, cm := range newClaimMaps {
+		var values []string
+		diags.Append(cm.Values.ElementsAs(ctx, &values, false)...)
+		if diags.HasError() {
+			return
+		}
+
+		tflog.Debug(ctx, "Setting claim map", map[string]any{
+			"claim":  cm.Claim.ValueString(),
+			"group":  cm.Group.ValueString(),
+			"values": values,
+		})
+		if err := r.client.SetOAuth2ClaimMap(ctx, name, cm.Claim.ValueString(), cm.Group.ValueString(), values); err != nil {
+			addAPIError(diags, "Error Setting Claim Map", "Could not set claim map: ", err, map[string]path.Path{
+				client.ErrCodeInvalidAttribute: path.Root("claim_map"),
+			})
+			return
+		}
+
+		join := client.OAuth2ClaimJoinArray
+		if !cm.Join.IsNull() && !cm.Join.IsUnknown() {
+			join = cm.Join.ValueString()
+		}
+		joins[cm.Claim.ValueString()] = join
+	}
+
+	for claim, join := range joins {
+		if err := r.client.SetOAuth2ClaimMapJoin(ctx, name, claim, join); err != nil {
+			addAPIError(diags, "Error Setting Claim Map", "Could not set claim map join: ", err, nil)
+			return
+		}
+	}
+}
+
+// scopeMapsByGroup decodes a scope_map style set into scopes keyed by group
+func scopeMapsByGroup(ctx context.Context, value types.Set, diags *diag.Diagnostics) map[string][]string {
+	var scopeMaps []scopeMapModel
+	elementsAs(ctx, value, &scopeMaps, diags)
+
+	byGroup := make(map[string][]string, len(scopeMaps))
+	for _, sm := range scopeMaps {
+		var scopes []string
+		diags.Append(sm.Scopes.ElementsAs(ctx, &scopes, false)...)
+		byGroup[sm.Group.ValueString()] = scopes
+	}
+	return byGroup
+}
+
+// setState copies the server view of the client into model. Group references
+// keep the form already used in model when they name the same group, and
+// lists keep their configured order; anything new is reported by group name.
+func (r *oauth2BasicResource) setState(ctx context.Context, oauth2Client *client.OAuth2Client, model *oauth2BasicResourceModel, diags *diag.Diagnostics) {
+	var priorRedirectURIs []string
+	elementsAs(ctx, model.RedirectURIs, &priorRedirectURIs, diags)
+	priorScopeMaps := scopeMapsByGroup(ctx, model.ScopeMaps, diags)
+	priorSupScopeMaps := scopeMapsByGroup(ctx, model.SupScopeMaps, diags)
+	var priorClaimMaps []claimMapModel
+	elementsAs(ctx, model.ClaimMaps, &priorClaimMaps, diags)
+	if diags.HasError() {
+		return
+	}
+
+	// Resolve every group Kanidm reported to the form used in configuration
+	var actualGroups, priorGroups []string
+	for group := range oauth2Client.ScopeMaps {
+		actualGroups = append(actualGroups, group)
+	}
+	for group := range oauth2Client.SupScopeMaps {
+		actualGroups = append(actualGroups, group)
+	}
+	for _, cm := range oauth2Client.ClaimMaps {
+		actualGroups = append(actualGroups, cm.Group)
+	}
+	for group := range priorScopeMaps {
+		priorGroups = append(priorGroups, group)
+	}
+	for group := range priorSupScopeMaps {
+		priorGroups = append(priorGroups, group)
+	}
+	priorClaimValues := make(map[[2]string][]string, len(priorClaimMaps))
+	for _, cm := range priorClaimMaps {
+		priorGroups = append(priorGroups, cm.Group.ValueString())
+
+		var values []string
+		diags.Append(cm.Values.ElementsAs(ctx, &values, false)...)
+		priorClaimValues[[2]string{cm.Claim.ValueString(), cm.Group.ValueString()}] = values
+	}
+
+	groupForms, err := r.client.MemberForms(ctx, actualGroups, priorGroups, true)
+	if err != nil {
+		diags.AddError(
+			"Error Resolving OAuth2 Groups",
+			"Could not resolve the groups referenced by the OAuth2 client: "+err.Error(),
+		)
+		return
+	}
+
+	model.Name = types.StringValue(oauth2Client.Name)
+	model.UUID = types.StringValue(oauth2Client.UUID)
+	model.DisplayName = types.StringValue(oauth2Client.DisplayName)
+	model.Origin = types.StringValue(oauth2Client.Origin)
+	model.AllowInsecureClientDisablePKCE = types.BoolValue(oauth2Client.Flags.AllowInsecureClientDisablePKCE)
+	model.LegacyCrypto = types.BoolValue(oauth2Client.Flags.LegacyCrypto)
+	model.PreferShortUsername = types.BoolValue(oauth2Client.Flags.PreferShortUsername)
+	model.StrictRedirectURI = types.BoolValue(oauth2Client.Flags.StrictRedirectURI)
+
+	if len(oauth2Client.RedirectURIs) > 0 {
+		redirectURIsList, d := types.ListValueFrom(ctx, types.StringType, orderLike(oauth2Client.RedirectURIs, priorRedirectURIs))
+		diags.Append(d...)
+		model.RedirectURIs = redirectURIsList
+	} else {
+		model.RedirectURIs = types.ListNull(types.StringType)
+	}
+
+	model.ScopeMaps = scopeMapsValue(ctx, model.ScopeMaps, oauth2Client.ScopeMaps, priorScopeMaps, groupForms, diags)
+	model.SupScopeMaps = scopeMapsValue(ctx, model.SupScopeMaps, oauth2Client.SupScopeMaps, priorSupScopeMaps, groupForms, diags)
+
+	claimMaps := make([]claimMapModel, 0, len(oauth2Client.ClaimMaps))
+	for _, cm := range oauth2Client.ClaimMaps {
+		group := groupForms[cm.Group]
+		values, d := types.ListValueFrom(ctx, types.StringType, orderLike(cm.Values, priorClaimValues[[2]string{cm.Claim, group}]))
+		diags.Append(d...)
+
+		claimMaps = append(claimMaps, claimMapModel{
+			Claim:  types.StringValue(cm.Claim),
+			Group:  types.StringValue(group),
+			Join:   types.StringValue(cm.Join),
+			Values: values,
+		})
+	}
+	model.ClaimMaps = nestedSetValue(ctx, model.ClaimMaps, claimMapObjectType, claimMaps, diags)
+}
+
+// scopeMapsValue builds a scope_map style set from the scope maps Kanidm reported
+func scopeMapsValue(ctx context.Context, prior types.Set, actual, priorByGroup map[string][]string, groupForms map[string]string, diags *diag.Diagnostics) types.Set {
+	scopeMaps := make([]scopeMapModel, 0, len(actual))
+	for group, scopes := range actual {
+		form := groupForms[group]
+		scopesList, d := types.ListValueFrom(ctx, types.StringType, orderLike(scopes, priorByGroup[form]))
+		diags.Append(d...)
+
+		scopeMaps = append(scopeMaps, scopeMapModel{
+			Group:  types.StringValue(form),
+			Scopes: scopesList,
+		})
+	}
+	return nestedSetValue(ctx, prior, scopeMapObjectType, scopeMaps, diags)
+}
+
+// nestedSetValue converts block elements to a set, keeping a null prior
+// value null when there are no elements so an absent block stays absent
+func nestedSetValue[T any](ctx context.Context, prior types.Set, elemType types.ObjectType, elements []T, diags *diag.Diagnostics) types.Set {
+	if len(elements) == 0 && prior.IsNull() {
+		return types.SetNull(elemType)
+	}
+
+	value, d := types.SetValueFrom(ctx, elemType, elements)
+	diags.Append(d...)
+	return value
+}
+
+// lookup finds the OAuth2 client by name, falling back to its UUID when the
+// name is a UUID (import) or the client was renamed outside Terraform
+func (r *oauth2BasicResource) lookup(ctx context.Context, name, uuid types.String) (*client.OAuth2Client, error) {
+	if client.IsUUID(name.ValueString()) {
+		return r.client.GetOAuth2ClientByUUID(ctx, name.ValueString())
+	}
+
+	oauth2Client, err := r.client.GetOAuth2Client(ctx, name.ValueString())
+	if errors.Is(err, client.ErrNotFound) && !uuid.IsNull() && uuid.ValueString() != "" {
+		return r.client.GetOAuth2ClientByUUID(ctx, uuid.ValueString())
+	}
+
+	return oauth2Client, err
+}
+
 func (r *oauth2BasicResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Use the name directly as the import identifier
-	resource.ImportStatePassthroughID(ctx, path.Root("name"), req, resp)
+	// Accept the client name, the UUID or an identity with the UUID; Read
+	// resolves each of them, replaces the name with the current client name
+	// and reconstructs the rest of the configuration, including scope maps,
+	// claim maps, settings and the secret
+	importEntryState(ctx, path.Root("name"), req, resp)
 
 	tflog.Debug(ctx, "Imported OAuth2 basic client", map[string]any{
 		"name": req.ID,
 	})
-
-	// Add a warning about the client secret
-	resp.Diagnostics.AddWarning(
-		"Client Secret Not Available",
-		"The client secret for this OAuth2 basic client is not available after import. "+
-			"If you need the secret, you must regenerate it manually using the Kanidm CLI (kanidm system oauth2 basic_secret_read).",
-	)
 }
@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ssoriche/terraform-provider-kanidm/internal/client"
+)
+
+// Ensure the implementation satisfies the required interfaces
+var (
+	_ ephemeral.EphemeralResource              = (*oauth2BasicSecretEphemeralResource)(nil)
+	_ ephemeral.EphemeralResourceWithConfigure = (*oauth2BasicSecretEphemeralResource)(nil)
+)
+
+// NewOAuth2BasicSecretEphemeralResource creates a new OAuth2 basic secret ephemeral resource
+func NewOAuth2BasicSecretEphemeralResource() ephemeral.EphemeralResource {
+	return &oauth2BasicSecretEphemeralResource{}
+}
+
+// oauth2BasicSecretEphemeralResource is the ephemeral resource implementation
+type oauth2BasicSecretEphemeralResource struct {
+	client *client.Client
+}
+
+// oauth2BasicSecretEphemeralResourceModel describes the ephemeral resource data model
+type oauth2BasicSecretEphemeralResourceModel struct {
+	Name         types.String `tfsdk:"name"`
+	ClientSecret types.String `tfsdk:"client_secret"`
+}
+
+// Metadata returns the ephemeral resource type name
+func (r *oauth2BasicSecretEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_oauth2_basic_secret"
+}
+
+// Schema defines the schema for the ephemeral resource
+func (r *oauth2BasicSecretEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Reads the current client secret of an OAuth2 basic client without storing it in state (Terraform 1.10+).
+
+## Example Usage
+
+` + "```hcl" + `
+ephemeral "kanidm_oauth2_basic_secret" "grafana" {
+  name = kanidm_oauth2_basic.grafana.name
+}
+
+resource "vault_kv_secret_v2" "grafana_oidc" {
+  mount                = "secret"
+  name                 = "grafana/oidc"
+  data_json_wo         = jsonencode({ client_secret = ephemeral.kanidm_oauth2_basic_secret.grafana.client_secret })
+  data_json_wo_version = 1
+}
+` + "```",
+
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the OAuth2 basic client, which is also its client ID.",
+				Required:            true,
+			},
+			"client_secret": schema.StringAttribute{
+				MarkdownDescription: "The current client secret.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the ephemeral resource
+func (r *oauth2BasicSecretEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			"Expected *client.Client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	r.client = c
+}
+
+// Open reads the client secret
+func (r *oauth2BasicSecretEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data oauth2BasicSecretEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading OAuth2 basic client secret", map[string]any{
+		"name": data.Name.ValueString(),
+	})
+
+	secret, err := r.client.GetOAuth2BasicSecret(ctx, data.Name.ValueString())
+	if errors.Is(err, client.ErrNoClientSecret) {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("name"),
+			"OAuth2 Client Has No Secret",
+			"The OAuth2 client "+data.Name.ValueString()+" has no client secret. Public clients authenticate "+
+				"with PKCE rather than a secret, so there is nothing to read.",
+		)
+		return
+	}
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error Reading Client Secret", "Could not read OAuth2 basic client secret: ", err, map[string]path.Path{
+			client.ErrCodeNoMatchingEntries: path.Root("name"),
+		})
+		return
+	}
+
+	data.ClientSecret = types.StringValue(secret)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
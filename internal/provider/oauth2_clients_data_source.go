@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ssoriche/terraform-provider-kanidm/internal/client"
+)
+
+var _ datasource.DataSourceWithConfigure = (*oauth2ClientsDataSource)(nil)
+
+func NewOAuth2ClientsDataSource() datasource.DataSource {
+	return &oauth2ClientsDataSource{}
+}
+
+type oauth2ClientsDataSource struct {
+	client *client.Client
+}
+
+type oauth2ClientsDataSourceModel struct {
+	NamePrefix types.String               `tfsdk:"name_prefix"`
+	Class      types.String               `tfsdk:"class"`
+	Filter     types.String               `tfsdk:"filter"`
+	Names      types.List                 `tfsdk:"names"`
+	Clients    []oauth2ClientSummaryModel `tfsdk:"clients"`
+}
+
+type oauth2ClientSummaryModel struct {
+	Name         types.String `tfsdk:"name"`
+	DisplayName  types.String `tfsdk:"displayname"`
+	Origin       types.String `tfsdk:"origin"`
+	RedirectURIs types.List   `tfsdk:"redirect_uris"`
+	IsPublic     types.Bool   `tfsdk:"is_public"`
+}
+
+func (d *oauth2ClientsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_oauth2_clients"
+}
+
+func (d *oauth2ClientsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attrs := searchFilterAttributes(false)
+	attrs["names"] = schema.ListAttribute{
+		MarkdownDescription: "Names of the matching OAuth2 clients, in the order returned by Kanidm.",
+		Computed:            true,
+		ElementType:         types.StringType,
+	}
+	attrs["clients"] = schema.ListNestedAttribute{
+		MarkdownDescription: "The matching OAuth2 clients.",
+		Computed:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{
+					MarkdownDescription: "Name (client ID) of the OAuth2 client.",
+					Computed:            true,
+				},
+				"displayname": schema.StringAttribute{
+					MarkdownDescription: "Display name of the OAuth2 client.",
+					Computed:            true,
+				},
+				"origin": schema.StringAttribute{
+					MarkdownDescription: "Origin URL of the OAuth2 client application.",
+					Computed:            true,
+				},
+				"redirect_uris": schema.ListAttribute{
+					MarkdownDescription: "Allowed redirect URIs for OAuth2 callbacks.",
+					Computed:            true,
+					ElementType:         types.StringType,
+				},
+				"is_public": schema.BoolAttribute{
+					MarkdownDescription: "Whether the client is a public client rather than a basic (confidential) client.",
+					Computed:            true,
+				},
+			},
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Lists Kanidm OAuth2 clients, optionally filtered.
+
+## Example Usage
+
+` + "```hcl" + `
+data "kanidm_oauth2_clients" "all" {}
+
+output "oauth2_client_names" {
+  value = data.kanidm_oauth2_clients.all.names
+}
+` + "```",
+		Attributes: attrs,
+	}
+}
+
+func (d *oauth2ClientsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *client.Client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	d.client = c
+}
+
+func (d *oauth2ClientsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state oauth2ClientsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter, diags := buildSearchFilter(types.StringNull(), state.Class, state.Filter)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Listing OAuth2 clients", map[string]any{
+		"filter": filter,
+	})
+
+	var clients []*client.OAuth2Client
+	var err error
+	if filter == nil {
+		clients, err = d.client.ListOAuth2Clients(ctx)
+	} else {
+		clients, err = d.client.SearchOAuth2Clients(ctx, filter)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing OAuth2 Clients",
+			"Could not list OAuth2 clients: "+err.Error(),
+		)
+		return
+	}
+
+	names := make([]string, 0, len(clients))
+	state.Clients = make([]oauth2ClientSummaryModel, 0, len(clients))
+	for _, oauth2Client := range clients {
+		if !matchesNamePrefix(oauth2Client.Name, state.NamePrefix) {
+			continue
+		}
+
+		redirectURIs := oauth2Client.RedirectURIs
+		if redirectURIs == nil {
+			redirectURIs = []string{}
+		}
+		redirectURIsList, diags := types.ListValueFrom(ctx, types.StringType, redirectURIs)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		names = append(names, oauth2Client.Name)
+		state.Clients = append(state.Clients, oauth2ClientSummaryModel{
+			Name:         types.StringValue(oauth2Client.Name),
+			DisplayName:  types.StringValue(oauth2Client.DisplayName),
+			Origin:       types.StringValue(oauth2Client.Origin),
+			RedirectURIs: redirectURIsList,
+			IsPublic:     types.BoolValue(oauth2Client.IsPublic),
+		})
+	}
+
+	namesList, diags := types.ListValueFrom(ctx, types.StringType, names)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Names = namesList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ssoriche/terraform-provider-kanidm/internal/client"
+)
+
+// Ensure the implementation satisfies the required interfaces
+var (
+	_ ephemeral.EphemeralResource              = (*personCredentialResetTokenEphemeralResource)(nil)
+	_ ephemeral.EphemeralResourceWithConfigure = (*personCredentialResetTokenEphemeralResource)(nil)
+)
+
+// NewPersonCredentialResetTokenEphemeralResource creates a new credential reset token ephemeral resource
+func NewPersonCredentialResetTokenEphemeralResource() ephemeral.EphemeralResource {
+	return &personCredentialResetTokenEphemeralResource{}
+}
+
+// personCredentialResetTokenEphemeralResource is the ephemeral resource implementation
+type personCredentialResetTokenEphemeralResource struct {
+	client *client.Client
+}
+
+// personCredentialResetTokenEphemeralResourceModel describes the ephemeral resource data model
+type personCredentialResetTokenEphemeralResourceModel struct {
+	ID         types.String `tfsdk:"id"`
+	TTL        types.Int64  `tfsdk:"ttl"`
+	Token      types.String `tfsdk:"token"`
+	ExpiryTime types.String `tfsdk:"expiry_time"`
+}
+
+// Metadata returns the ephemeral resource type name
+func (r *personCredentialResetTokenEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_person_credential_reset_token"
+}
+
+// Schema defines the schema for the ephemeral resource
+func (r *personCredentialResetTokenEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Issues a credential reset token for a person account without storing it in state (Terraform 1.10+).
+
+A new token is issued every time the ephemeral resource is opened, so it can be passed straight to
+a write-only argument, such as a secret manager entry or an email, and re-issued on demand.
+
+## Example Usage
+
+` + "```hcl" + `
+ephemeral "kanidm_person_credential_reset_token" "alice" {
+  id  = kanidm_person.alice.id
+  ttl = 86400
+}
+
+resource "vault_kv_secret_v2" "alice_reset" {
+  mount                = "secret"
+  name                 = "kanidm/alice-reset"
+  data_json_wo         = jsonencode({ token = ephemeral.kanidm_person_credential_reset_token.alice.token })
+  data_json_wo_version = 1
+}
+` + "```",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Name, SPN or UUID of the person account.",
+				Required:            true,
+			},
+			"ttl": schema.Int64Attribute{
+				MarkdownDescription: "Time-to-live for the token in seconds. Defaults to 3600 (1 hour).",
+				Optional:            true,
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "The credential reset token. It can be used once to set up credentials via the Kanidm web UI.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"expiry_time": schema.StringAttribute{
+				MarkdownDescription: "RFC 3339 time after which the token can no longer be used.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the ephemeral resource
+func (r *personCredentialResetTokenEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			"Expected *client.Client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	r.client = c
+}
+
+// Open issues a new credential reset token
+func (r *personCredentialResetTokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data personCredentialResetTokenEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Issuing credential reset token", map[string]any{
+		"id": data.ID.ValueString(),
+	})
+
+	ttl := defaultCredentialResetTokenTTL
+	if !data.TTL.IsNull() {
+		ttl = int(data.TTL.ValueInt64())
+	}
+
+	token, err := r.client.CreatePersonCredentialResetToken(ctx, data.ID.ValueString(), &ttl)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error Generating Credential Reset Token", "Could not generate credential reset token: ", err, map[string]path.Path{
+			client.ErrCodeNoMatchingEntries: path.Root("id"),
+		})
+		return
+	}
+
+	data.Token = types.StringValue(token.Token)
+	data.ExpiryTime = types.StringValue(token.ExpiryTime)
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+}
@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ssoriche/terraform-provider-kanidm/internal/client"
+)
+
+var _ datasource.DataSourceWithConfigure = (*personDataSource)(nil)
+
+func NewPersonDataSource() datasource.DataSource {
+	return &personDataSource{}
+}
+
+type personDataSource struct {
+	client *client.Client
+}
+
+type personDataSourceModel struct {
+	ID          types.String `tfsdk:"id"`
+	UUID        types.String `tfsdk:"uuid"`
+	SPN         types.String `tfsdk:"spn"`
+	DisplayName types.String `tfsdk:"displayname"`
+	Mail        types.List   `tfsdk:"mail"`
+	MemberOf    types.Set    `tfsdk:"memberof"`
+}
+
+func (d *personDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_person"
+}
+
+func (d *personDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Looks up an existing Kanidm person account.
+
+## Example Usage
+
+` + "```hcl" + `
+data "kanidm_person" "alice" {
+  id = "alice"
+}
+
+output "alice_mail" {
+  value = data.kanidm_person.alice.mail
+}
+` + "```",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Name, SPN or UUID of the person account to look up.",
+				Required:            true,
+			},
+			"uuid": schema.StringAttribute{
+				MarkdownDescription: "Immutable UUID of the person account.",
+				Computed:            true,
+			},
+			"spn": schema.StringAttribute{
+				MarkdownDescription: "Security principal name of the person account (e.g., alice@idm.example.com).",
+				Computed:            true,
+			},
+			"displayname": schema.StringAttribute{
+				MarkdownDescription: "Display name of the person.",
+				Computed:            true,
+			},
+			"mail": schema.ListAttribute{
+				MarkdownDescription: "Email addresses for the person.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"memberof": schema.SetAttribute{
+				MarkdownDescription: "Groups the person belongs to, directly or through nested groups, as reported by Kanidm.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+		},
+	}
+}
+
+func (d *personDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *client.Client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	d.client = c
+}
+
+func (d *personDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config personDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading person data source", map[string]any{
+		"id": config.ID.ValueString(),
+	})
+
+	person, err := d.client.GetPerson(ctx, config.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("id"),
+				"Person Not Found",
+				"No person account named "+config.ID.ValueString()+" exists.",
+			)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Reading Person",
+			"Could not read person: "+err.Error(),
+		)
+		return
+	}
+
+	state := personDataSourceModel{
+		ID:          config.ID,
+		UUID:        types.StringValue(person.UUID),
+		SPN:         types.StringValue(person.SPN),
+		DisplayName: types.StringValue(person.DisplayName),
+	}
+
+	// Always set mail as a list (empty if no addresses)
+	mail := person.Mail
+	if mail == nil {
+		mail = []string{}
+	}
+
+	mailList, diags := types.ListValueFrom(ctx, types.StringType, mail)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Mail = mailList
+
+	// Always set memberof as a set (empty if no memberships)
+	memberOf := person.MemberOf
+	if memberOf == nil {
+		memberOf = []string{}
+	}
+
+	memberOfSet, diags := types.SetValueFrom(ctx, types.StringType, memberOf)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.MemberOf = memberOfSet
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
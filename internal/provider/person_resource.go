@@ -2,8 +2,11 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -18,10 +21,28 @@ import (
 
 // Ensure the implementation satisfies the required interfaces
 var (
-	_ resource.Resource                = (*personResource)(nil)
-	_ resource.ResourceWithImportState = (*personResource)(nil)
+	_ resource.Resource                   = (*personResource)(nil)
+	_ resource.ResourceWithImportState    = (*personResource)(nil)
+	_ resource.ResourceWithIdentity       = (*personResource)(nil)
+	_ resource.ResourceWithValidateConfig = (*personResource)(nil)
+	_ resource.ResourceWithUpgradeState   = (*personResource)(nil)
+	_ resource.ResourceWithModifyPlan     = (*personResource)(nil)
 )
 
+// defaultCredentialResetTokenTTL is the lifetime of credential reset tokens in seconds
+const defaultCredentialResetTokenTTL = 3600
+
+// personAccountExpirePrivateKey is the private state key holding the account
+// expiry that was scheduled before Terraform locked the account
+const personAccountExpirePrivateKey = "account_expire"
+
+// privateState is the provider data Terraform keeps alongside a resource's
+// state without exposing it in configuration
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
 // NewPersonResource creates a new person resource
 func NewPersonResource() resource.Resource {
 	return &personResource{}
@@ -35,12 +56,18 @@ type personResource struct {
 // personResourceModel describes the resource data model
 type personResourceModel struct {
 	ID                           types.String `tfsdk:"id"`
+	UUID                         types.String `tfsdk:"uuid"`
 	DisplayName                  types.String `tfsdk:"displayname"`
 	Mail                         types.List   `tfsdk:"mail"`
 	Password                     types.String `tfsdk:"password"`
+	PasswordVersion              types.Int64  `tfsdk:"password_version"`
 	GenerateCredentialResetToken types.Bool   `tfsdk:"generate_credential_reset_token"`
 	CredentialResetToken         types.String `tfsdk:"credential_reset_token"`
 	CredentialResetTokenTTL      types.Int64  `tfsdk:"credential_reset_token_ttl"`
+	CredentialResetTokenVersion  types.Int64  `tfsdk:"credential_reset_token_version"`
+	Locked                       types.Bool   `tfsdk:"locked"`
+	LockOnDestroy                types.Bool   `tfsdk:"lock_on_destroy"`
+	DeletionProtection           types.Bool   `tfsdk:"deletion_protection"`
 }
 
 // Metadata returns the resource type name
@@ -51,6 +78,8 @@ func (r *personResource) Metadata(_ context.Context, req resource.MetadataReques
 // Schema defines the schema for the resource
 func (r *personResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
+		// Version 1 made password write-only
+		Version: 1,
 		MarkdownDescription: `Manages a Kanidm person account.
 
 ## Authentication Setup
@@ -58,13 +87,15 @@ func (r *personResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 Kanidm supports two credential setup workflows:
 
 ### Password-Based Authentication
-Set the ` + "`password`" + ` attribute to create a password-based account:
+Set the write-only ` + "`password`" + ` attribute to create a password-based account. The password is never
+stored in state, so bump ` + "`password_version`" + ` whenever it should be pushed again:
 
 ` + "```hcl" + `
 resource "kanidm_person" "example" {
-  id          = "jdoe"
-  displayname = "John Doe"
-  password    = var.initial_password
+  id               = "jdoe"
+  displayname      = "John Doe"
+  password         = var.initial_password
+  password_version = 1
 }
 ` + "```" + `
 
@@ -84,14 +115,34 @@ output "credential_reset_token" {
 }
 ` + "```" + `
 
-The user can then visit the Kanidm web UI with the token to set up passkeys or passwords.`,
+The user can then visit the Kanidm web UI with the token to set up passkeys or passwords.
+
+## Offboarding
+
+Set ` + "`locked = true`" + ` to stop the person from authenticating while keeping the account, its
+credentials and its group memberships. With ` + "`lock_on_destroy = true`" + `, removing the resource locks
+the account instead of deleting it, so it can be retained and deleted later in Kanidm:
+
+` + "```hcl" + `
+resource "kanidm_person" "example" {
+  id              = "jdoe"
+  displayname     = "John Doe"
+  locked          = true
+  lock_on_destroy = true
+}
+` + "```",
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				MarkdownDescription: "Unique identifier for the person account (username). Cannot be changed after creation.",
-				Required:            true,
+				MarkdownDescription: "Unique identifier for the person account (username). " +
+					"Changing this renames the account in place; it keeps its UUID, credentials and group memberships.",
+				Required: true,
+			},
+			"uuid": schema.StringAttribute{
+				MarkdownDescription: "Immutable UUID of the person account, used to track the account across renames.",
+				Computed:            true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
 			"displayname": schema.StringAttribute{
@@ -104,11 +155,17 @@ The user can then visit the Kanidm web UI with the token to set up passkeys or p
 				ElementType:         types.StringType,
 			},
 			"password": schema.StringAttribute{
-				MarkdownDescription: "Password for the person account. **Note:** This is write-only and will not be stored in state. " +
-					"Mutually exclusive with `generate_credential_reset_token`. " +
-					"Consider using `lifecycle { ignore_changes = [password] }` if the password is managed externally.",
+				MarkdownDescription: "Password for the person account. This is a write-only attribute (Terraform 1.11+): it is set " +
+					"when the account is created and whenever `password_version` changes, and is never stored in state. " +
+					"Mutually exclusive with `generate_credential_reset_token`.",
 				Optional:  true,
 				Sensitive: true,
+				WriteOnly: true,
+			},
+			"password_version": schema.Int64Attribute{
+				MarkdownDescription: "Change this value to push the current `password` to Kanidm again. " +
+					"Changing the password alone has no effect, as write-only values are not compared between runs.",
+				Optional: true,
 			},
 			"generate_credential_reset_token": schema.BoolAttribute{
 				MarkdownDescription: "Whether to generate a credential reset token for passkey/password setup via the web UI. " +
@@ -119,7 +176,8 @@ The user can then visit the Kanidm web UI with the token to set up passkeys or p
 			},
 			"credential_reset_token": schema.StringAttribute{
 				MarkdownDescription: "The credential reset token (generated when `generate_credential_reset_token` is `true`). " +
-					"This token can be used once to set up credentials via the Kanidm web UI. **Computed value only.**",
+					"This token can be used once to set up credentials via the Kanidm web UI. **Computed value only.** " +
+					"It is stored in state; use the `kanidm_person_credential_reset_token` ephemeral resource to avoid that.",
 				Computed:  true,
 				Sensitive: true,
 			},
@@ -127,12 +185,40 @@ The user can then visit the Kanidm web UI with the token to set up passkeys or p
 				MarkdownDescription: "Time-to-live for the credential reset token in seconds. Defaults to 3600 (1 hour).",
 				Optional:            true,
 				Computed:            true,
-				Default:             int64default.StaticInt64(3600),
+				Default:             int64default.StaticInt64(defaultCredentialResetTokenTTL),
 			},
+			"credential_reset_token_version": schema.Int64Attribute{
+				MarkdownDescription: "Change this value to issue a new `credential_reset_token` while " +
+					"`generate_credential_reset_token` is `true`, e.g. after the previous one expired.",
+				Optional: true,
+			},
+			"locked": schema.BoolAttribute{
+				MarkdownDescription: "Whether the account is locked. Locking sets the account expiry to the current time so the " +
+					"person can no longer authenticate. Unlocking clears it, or restores an expiry that was scheduled " +
+					"for later when the account was locked. An expiry set outside Terraform that has passed is reported " +
+					"as locked. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"lock_on_destroy": schema.BoolAttribute{
+				MarkdownDescription: "Whether destroying the resource locks the account instead of deleting it. The locked " +
+					"account is removed from state and must be deleted in Kanidm once it is no longer retained. " +
+					"Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"deletion_protection": deletionProtectionAttribute(),
 		},
 	}
 }
 
+// IdentitySchema defines the resource identity, keyed on the entry UUID
+func (r *personResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = entryIdentitySchema()
+}
+
 // Configure adds the provider configured client to the resource
 func (r *personResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
@@ -151,6 +237,83 @@ func (r *personResource) Configure(_ context.Context, req resource.ConfigureRequ
 	r.client = c
 }
 
+// ValidateConfig checks that only one credential setup method is configured
+func (r *personResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var password types.String
+	var generateToken types.Bool
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password"), &password)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("generate_credential_reset_token"), &generateToken)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !password.IsNull() && generateToken.ValueBool() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password"),
+			"Conflicting Configuration",
+			"Cannot specify both 'password' and 'generate_credential_reset_token'. Choose one authentication setup method.",
+		)
+	}
+}
+
+// ModifyPlan refuses to plan the destruction of a protected person
+func (r *personResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPlanProtection(ctx, r.client, req, resp, entryNamePaths)
+}
+
+// UpgradeState migrates state written before password became write-only,
+// dropping the plaintext password earlier versions stored
+func (r *personResource) UpgradeState(_ context.Context) map[int64]resource.StateUpgrader {
+	return map[int64]resource.StateUpgrader{
+		0: {
+			PriorSchema: &schema.Schema{
+				Attributes: map[string]schema.Attribute{
+					"id":                              schema.StringAttribute{Required: true},
+					"uuid":                            schema.StringAttribute{Computed: true},
+					"displayname":                     schema.StringAttribute{Required: true},
+					"mail":                            schema.ListAttribute{Optional: true, ElementType: types.StringType},
+					"password":                        schema.StringAttribute{Optional: true, Sensitive: true},
+					"generate_credential_reset_token": schema.BoolAttribute{Optional: true, Computed: true},
+					"credential_reset_token":          schema.StringAttribute{Computed: true, Sensitive: true},
+					"credential_reset_token_ttl":      schema.Int64Attribute{Optional: true, Computed: true},
+				},
+			},
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				var prior struct {
+					ID                           types.String `tfsdk:"id"`
+					UUID                         types.String `tfsdk:"uuid"`
+					DisplayName                  types.String `tfsdk:"displayname"`
+					Mail                         types.List   `tfsdk:"mail"`
+					Password                     types.String `tfsdk:"password"`
+					GenerateCredentialResetToken types.Bool   `tfsdk:"generate_credential_reset_token"`
+					CredentialResetToken         types.String `tfsdk:"credential_reset_token"`
+					CredentialResetTokenTTL      types.Int64  `tfsdk:"credential_reset_token_ttl"`
+				}
+				resp.Diagnostics.Append(req.State.Get(ctx, &prior)...)
+				if resp.Diagnostics.HasError() {
+					return
+				}
+
+				resp.Diagnostics.Append(resp.State.Set(ctx, personResourceModel{
+					ID:                           prior.ID,
+					UUID:                         prior.UUID,
+					DisplayName:                  prior.DisplayName,
+					Mail:                         prior.Mail,
+					Password:                     types.StringNull(),
+					PasswordVersion:              types.Int64Null(),
+					GenerateCredentialResetToken: prior.GenerateCredentialResetToken,
+					CredentialResetToken:         prior.CredentialResetToken,
+					CredentialResetTokenTTL:      prior.CredentialResetTokenTTL,
+					CredentialResetTokenVersion:  types.Int64Null(),
+					Locked:                       types.BoolNull(),
+					LockOnDestroy:                types.BoolNull(),
+					DeletionProtection:           types.BoolNull(),
+				})...)
+			},
+		},
+	}
+}
+
 // Create creates the resource and sets the initial Terraform state
 func (r *personResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan personResourceModel
@@ -159,40 +322,35 @@ func (r *personResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	// Validate mutually exclusive options
-	hasPassword := !plan.Password.IsNull() && !plan.Password.IsUnknown()
-	generateToken := plan.GenerateCredentialResetToken.ValueBool()
-
-	if hasPassword && generateToken {
-		resp.Diagnostics.AddError(
-			"Conflicting Configuration",
-			"Cannot specify both 'password' and 'generate_credential_reset_token'. Choose one authentication setup method.",
-		)
+	// Password is write-only and only present in the configuration
+	var password types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password"), &password)...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
 
+	hasPassword := !password.IsNull() && !password.IsUnknown()
+	generateToken := plan.GenerateCredentialResetToken.ValueBool()
+
 	tflog.Debug(ctx, "Creating person", map[string]any{
 		"id": plan.ID.ValueString(),
 	})
 
-	// Create the person account
-	person, err := r.client.CreatePerson(ctx, plan.ID.ValueString(), plan.DisplayName.ValueString())
+	// Create the person account, or revive it from the recycle bin
+	person, err := r.createPerson(ctx, &plan, &resp.Diagnostics)
 	if err != nil {
-		resp.Diagnostics.AddError(
-			"Error Creating Person",
-			"Could not create person: "+err.Error(),
-		)
+		addAPIError(&resp.Diagnostics, "Error Creating Person", "Could not create person: ", err, map[string]path.Path{
+			client.ErrCodeAttributeUniqueness: path.Root("id"),
+			client.ErrCodeValueDenyName:       path.Root("id"),
+		})
 		return
 	}
 
 	// Set password if provided
 	if hasPassword {
 		tflog.Debug(ctx, "Setting initial password for person")
-		if err := r.client.SetPersonPassword(ctx, person.ID, plan.Password.ValueString()); err != nil {
-			resp.Diagnostics.AddError(
-				"Error Setting Password",
-				"Person was created but password could not be set: "+err.Error(),
-			)
+		if err := r.client.SetPersonPassword(ctx, person.ID, password.ValueString()); err != nil {
+			addPasswordError(&resp.Diagnostics, "Error Setting Password", "Person was created but password could not be set: ", err)
 			return
 		}
 	}
@@ -209,7 +367,9 @@ func (r *personResource) Create(ctx context.Context, req resource.CreateRequest,
 			)
 			return
 		}
-		plan.CredentialResetToken = types.StringValue(token)
+		plan.CredentialResetToken = types.StringValue(token.Token)
+	} else {
+		plan.CredentialResetToken = types.StringNull()
 	}
 
 	// Update mail if provided
@@ -232,6 +392,17 @@ func (r *personResource) Create(ctx context.Context, req resource.CreateRequest,
 		}
 	}
 
+	if plan.Locked.ValueBool() {
+		tflog.Debug(ctx, "Locking person")
+		if err := r.client.LockPerson(ctx, person.ID, time.Now()); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Locking Person",
+				"Person was created but could not be locked: "+err.Error(),
+			)
+			return
+		}
+	}
+
 	// Read back the person to get the current state
 	createdPerson, err := r.client.GetPerson(ctx, person.ID)
 	if err != nil {
@@ -244,7 +415,9 @@ func (r *personResource) Create(ctx context.Context, req resource.CreateRequest,
 
 	// Map response to state
 	plan.ID = types.StringValue(createdPerson.ID)
+	plan.UUID = types.StringValue(createdPerson.UUID)
 	plan.DisplayName = types.StringValue(createdPerson.DisplayName)
+	plan.Locked = types.BoolValue(createdPerson.IsLocked(time.Now()))
 
 	if len(createdPerson.Mail) > 0 {
 		mailList, diags := types.ListValueFrom(ctx, types.StringType, createdPerson.Mail)
@@ -255,13 +428,15 @@ func (r *personResource) Create(ctx context.Context, req resource.CreateRequest,
 		plan.Mail = mailList
 	}
 
-	// Password is write-only, keep the planned value but don't try to read it back
+	// Password is write-only and never stored
+	plan.Password = types.StringNull()
 
 	tflog.Debug(ctx, "Person created successfully", map[string]any{
 		"id": plan.ID.ValueString(),
 	})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	setEntryIdentity(ctx, resp.Identity, plan.UUID, &resp.Diagnostics)
 }
 
 // Read refreshes the Terraform state with the latest data
@@ -276,8 +451,8 @@ func (r *personResource) Read(ctx context.Context, req resource.ReadRequest, res
 		"id": state.ID.ValueString(),
 	})
 
-	// Get current person from API
-	person, err := r.client.GetPerson(ctx, state.ID.ValueString())
+	// Get current person from API, by UUID once known so renames are followed
+	person, err := r.client.GetPerson(ctx, entryRef(state.UUID, state.ID))
 	if err != nil {
 		if errors.Is(err, client.ErrNotFound) {
 			tflog.Warn(ctx, "Person not found, removing from state", map[string]any{
@@ -296,7 +471,9 @@ func (r *personResource) Read(ctx context.Context, req resource.ReadRequest, res
 
 	// Update state with current values
 	state.ID = types.StringValue(person.ID)
+	state.UUID = types.StringValue(person.UUID)
 	state.DisplayName = types.StringValue(person.DisplayName)
+	state.Locked = types.BoolValue(person.IsLocked(time.Now()))
 
 	if len(person.Mail) > 0 {
 		mailList, diags := types.ListValueFrom(ctx, types.StringType, person.Mail)
@@ -309,9 +486,25 @@ func (r *personResource) Read(ctx context.Context, req resource.ReadRequest, res
 		state.Mail = types.ListNull(types.StringType)
 	}
 
-	// Password and credential_reset_token are write-only, preserve existing state values
+	// Input-only settings cannot be read back. Fill in their defaults when
+	// they are unset (e.g. after import) so the first plan is empty.
+	if state.GenerateCredentialResetToken.IsNull() {
+		state.GenerateCredentialResetToken = types.BoolValue(false)
+	}
+	if state.CredentialResetTokenTTL.IsNull() {
+		state.CredentialResetTokenTTL = types.Int64Value(defaultCredentialResetTokenTTL)
+	}
+	if state.LockOnDestroy.IsNull() {
+		state.LockOnDestroy = types.BoolValue(false)
+	}
+	state.DeletionProtection = deletionProtectionValue(state.DeletionProtection)
+
+	// Password is write-only; clear any value persisted by earlier provider
+	// versions. credential_reset_token cannot be read back, preserve it.
+	state.Password = types.StringNull()
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	setEntryIdentity(ctx, resp.Identity, state.UUID, &resp.Diagnostics)
 }
 
 // Update updates the resource and sets the updated Terraform state
@@ -328,6 +521,23 @@ func (r *personResource) Update(ctx context.Context, req resource.UpdateRequest,
 		"id": plan.ID.ValueString(),
 	})
 
+	ref := entryRef(state.UUID, state.ID)
+
+	// Rename in place if the username changed
+	if !plan.ID.Equal(state.ID) {
+		tflog.Debug(ctx, "Renaming person", map[string]any{
+			"from": state.ID.ValueString(),
+			"to":   plan.ID.ValueString(),
+		})
+		if err := r.client.RenameEntry(ctx, client.EntryKindPerson, ref, plan.ID.ValueString()); err != nil {
+			addAPIError(&resp.Diagnostics, "Error Renaming Person", "Could not rename person: ", err, map[string]path.Path{
+				client.ErrCodeAttributeUniqueness: path.Root("id"),
+				client.ErrCodeValueDenyName:       path.Root("id"),
+			})
+			return
+		}
+	}
+
 	// Prepare mail addresses
 	var mailAddrs []string
 	if !plan.Mail.IsNull() && !plan.Mail.IsUnknown() {
@@ -338,7 +548,7 @@ func (r *personResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	// Update person attributes (displayname and mail)
-	if err := r.client.UpdatePerson(ctx, plan.ID.ValueString(), plan.DisplayName.ValueString(), mailAddrs); err != nil {
+	if err := r.client.UpdatePerson(ctx, ref, plan.DisplayName.ValueString(), mailAddrs); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Person",
 			"Could not update person: "+err.Error(),
@@ -346,23 +556,32 @@ func (r *personResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	// Update password if changed
-	if !plan.Password.Equal(state.Password) && !plan.Password.IsNull() {
-		tflog.Debug(ctx, "Updating password for person")
-		if err := r.client.SetPersonPassword(ctx, plan.ID.ValueString(), plan.Password.ValueString()); err != nil {
-			resp.Diagnostics.AddError(
-				"Error Updating Password",
-				"Person was updated but password could not be changed: "+err.Error(),
-			)
+	// Push the password again when its version changes, as write-only values
+	// cannot be compared with the previous run
+	if !plan.PasswordVersion.Equal(state.PasswordVersion) {
+		var password types.String
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("password"), &password)...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
+
+		if !password.IsNull() {
+			tflog.Debug(ctx, "Updating password for person")
+			if err := r.client.SetPersonPassword(ctx, ref, password.ValueString()); err != nil {
+				addPasswordError(&resp.Diagnostics, "Error Updating Password", "Person was updated but password could not be changed: ", err)
+				return
+			}
+		}
 	}
 
-	// Generate new credential reset token if requested and changed
-	if plan.GenerateCredentialResetToken.ValueBool() && !plan.GenerateCredentialResetToken.Equal(state.GenerateCredentialResetToken) {
+	// Generate a new credential reset token when it is first requested or its
+	// version changes
+	reissue := !plan.GenerateCredentialResetToken.Equal(state.GenerateCredentialResetToken) ||
+		!plan.CredentialResetTokenVersion.Equal(state.CredentialResetTokenVersion)
+	if plan.GenerateCredentialResetToken.ValueBool() && reissue {
 		tflog.Debug(ctx, "Generating new credential reset token for person")
 		ttl := int(plan.CredentialResetTokenTTL.ValueInt64())
-		token, err := r.client.CreatePersonCredentialResetToken(ctx, plan.ID.ValueString(), &ttl)
+		token, err := r.client.CreatePersonCredentialResetToken(ctx, ref, &ttl)
 		if err != nil {
 			resp.Diagnostics.AddError(
 				"Error Generating Credential Reset Token",
@@ -370,11 +589,35 @@ func (r *personResource) Update(ctx context.Context, req resource.UpdateRequest,
 			)
 			return
 		}
-		plan.CredentialResetToken = types.StringValue(token)
+		plan.CredentialResetToken = types.StringValue(token.Token)
+	} else {
+		plan.CredentialResetToken = state.CredentialResetToken
+	}
+
+	// Lock or unlock the account when requested
+	if !plan.Locked.Equal(state.Locked) {
+		var err error
+		if plan.Locked.ValueBool() {
+			tflog.Debug(ctx, "Locking person")
+			err = r.lockPerson(ctx, ref, resp.Private, &resp.Diagnostics)
+		} else {
+			tflog.Debug(ctx, "Unlocking person")
+			err = r.unlockPerson(ctx, ref, resp.Private, &resp.Diagnostics)
+		}
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Locking Person",
+				"Person was updated but could not be locked or unlocked: "+err.Error(),
+			)
+			return
+		}
 	}
 
 	// Read back the updated person
-	updatedPerson, err := r.client.GetPerson(ctx, plan.ID.ValueString())
+	updatedPerson, err := r.client.GetPerson(ctx, ref)
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Person",
@@ -384,8 +627,11 @@ func (r *personResource) Update(ctx context.Context, req resource.UpdateRequest,
 	}
 
 	// Update state
+	plan.Password = types.StringNull()
 	plan.ID = types.StringValue(updatedPerson.ID)
+	plan.UUID = types.StringValue(updatedPerson.UUID)
 	plan.DisplayName = types.StringValue(updatedPerson.DisplayName)
+	plan.Locked = types.BoolValue(updatedPerson.IsLocked(time.Now()))
 
 	if len(updatedPerson.Mail) > 0 {
 		mailList, diags := types.ListValueFrom(ctx, types.StringType, updatedPerson.Mail)
@@ -403,6 +649,7 @@ func (r *personResource) Update(ctx context.Context, req resource.UpdateRequest,
 	})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	setEntryIdentity(ctx, resp.Identity, plan.UUID, &resp.Diagnostics)
 }
 
 // Delete deletes the resource and removes the Terraform state
@@ -417,8 +664,38 @@ func (r *personResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		"id": state.ID.ValueString(),
 	})
 
+	checkDeletionProtection(ctx, r.client, req.State, entryNamePaths, "destroyed", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	ref := entryRef(state.UUID, state.ID)
+
+	// Lock the account instead of deleting it, leaving deletion to Kanidm
+	if state.LockOnDestroy.ValueBool() {
+		if err := r.client.LockPerson(ctx, ref, time.Now()); err != nil {
+			if errors.Is(err, client.ErrNotFound) {
+				tflog.Warn(ctx, "Person not found during delete, removing from state", map[string]any{
+					"id": state.ID.ValueString(),
+				})
+				return
+			}
+
+			resp.Diagnostics.AddError(
+				"Error Locking Person",
+				"Could not lock person on destroy: "+err.Error(),
+			)
+			return
+		}
+
+		tflog.Warn(ctx, "Person locked instead of deleted, removing from state", map[string]any{
+			"id": state.ID.ValueString(),
+		})
+		return
+	}
+
 	// Delete the person
-	if err := r.client.DeletePerson(ctx, state.ID.ValueString()); err != nil {
+	if err := r.client.DeletePerson(ctx, ref); err != nil {
 		if errors.Is(err, client.ErrNotFound) {
 			// Person already deleted, just remove from state
 			tflog.Warn(ctx, "Person not found during delete, removing from state", map[string]any{
@@ -439,12 +716,109 @@ func (r *personResource) Delete(ctx context.Context, req resource.DeleteRequest,
 	})
 }
 
+// createPerson creates the person account, or revives a recycled person with
+// the same name when the provider deletion_mode is "recycle". A revived person
+// keeps its UUID, credentials and memberships; its mail addresses and expiry
+// are cleared so only the configured ones are set.
+func (r *personResource) createPerson(ctx context.Context, plan *personResourceModel, diags *diag.Diagnostics) (*client.Person, error) {
+	name := plan.ID.ValueString()
+
+	revived, err := reviveRecycled(ctx, r.client, client.EntryKindPerson, name, diags)
+	if err != nil {
+		return nil, err
+	}
+	if !revived {
+		return r.client.CreatePerson(ctx, name, plan.DisplayName.ValueString())
+	}
+
+	tflog.Info(ctx, "Revived person from the recycle bin", map[string]any{
+		"id": name,
+	})
+
+	if err := r.client.UpdatePerson(ctx, name, plan.DisplayName.ValueString(), []string{}); err != nil {
+		return nil, err
+	}
+	if err := r.client.UnlockPerson(ctx, name, ""); err != nil {
+		return nil, err
+	}
+
+	return &client.Person{ID: name}, nil
+}
+
+// lockPerson locks the account now. An expiry scheduled for later is kept in
+// private state so unlocking restores it rather than clearing it.
+func (r *personResource) lockPerson(ctx context.Context, ref string, private privateState, diags *diag.Diagnostics) error {
+	person, err := r.client.GetPerson(ctx, ref)
+	if err != nil {
+		return err
+	}
+
+	// An expiry that has already passed, e.g. from an earlier lock, is not
+	// worth restoring, so whatever was remembered before is kept
+	now := time.Now()
+	if person.AccountExpire != "" && !person.IsLocked(now) {
+		value, err := json.Marshal(person.AccountExpire)
+		if err != nil {
+			return err
+		}
+		diags.Append(private.SetKey(ctx, personAccountExpirePrivateKey, value)...)
+		if diags.HasError() {
+			return nil
+		}
+	}
+
+	return r.client.LockPerson(ctx, ref, now)
+}
+
+// unlockPerson unlocks the account, restoring the expiry remembered by
+// lockPerson if it has not passed yet
+func (r *personResource) unlockPerson(ctx context.Context, ref string, private privateState, diags *diag.Diagnostics) error {
+	value, d := private.GetKey(ctx, personAccountExpirePrivateKey)
+	diags.Append(d...)
+	if diags.HasError() {
+		return nil
+	}
+
+	var restore string
+	if len(value) > 0 {
+		if err := json.Unmarshal(value, &restore); err != nil {
+			return err
+		}
+		if expire, err := time.Parse(time.RFC3339, restore); err != nil || !expire.After(time.Now()) {
+			restore = ""
+		}
+	}
+
+	if err := r.client.UnlockPerson(ctx, ref, restore); err != nil {
+		return err
+	}
+
+	diags.Append(private.SetKey(ctx, personAccountExpirePrivateKey, nil)...)
+	return nil
+}
+
 // ImportState imports an existing person into Terraform state
 func (r *personResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Use the ID (username) directly as the import identifier
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// Accept the username, the UUID or an identity with the UUID; Read
+	// resolves each of them and replaces the id with the current username
+	importEntryState(ctx, path.Root("id"), req, resp)
 
 	tflog.Debug(ctx, "Imported person", map[string]any{
 		"id": req.ID,
 	})
 }
+
+// addPasswordError reports a failure to set the password, attached to the
+// password attribute when Kanidm rejected the password itself
+func addPasswordError(diags *diag.Diagnostics, summary, detail string, err error) {
+	if errors.Is(err, client.ErrCredentialUpdateIncomplete) {
+		diags.AddAttributeError(path.Root("password"), summary, detail+err.Error()+
+			"\n\nThe account policy requires more than a password. Use generate_credential_reset_token "+
+			"so the user can enrol the remaining credentials.")
+		return
+	}
+
+	addAPIError(diags, summary, detail, err, map[string]path.Path{
+		client.ErrCodePasswordQuality: path.Root("password"),
+	})
+}
@@ -0,0 +1,184 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ssoriche/terraform-provider-kanidm/internal/client"
+)
+
+var _ datasource.DataSourceWithConfigure = (*personsDataSource)(nil)
+
+func NewPersonsDataSource() datasource.DataSource {
+	return &personsDataSource{}
+}
+
+type personsDataSource struct {
+	client *client.Client
+}
+
+type personsDataSourceModel struct {
+	NamePrefix types.String         `tfsdk:"name_prefix"`
+	MemberOf   types.String         `tfsdk:"member_of"`
+	Class      types.String         `tfsdk:"class"`
+	Filter     types.String         `tfsdk:"filter"`
+	IDs        types.List           `tfsdk:"ids"`
+	Persons    []personSummaryModel `tfsdk:"persons"`
+}
+
+type personSummaryModel struct {
+	ID          types.String `tfsdk:"id"`
+	UUID        types.String `tfsdk:"uuid"`
+	SPN         types.String `tfsdk:"spn"`
+	DisplayName types.String `tfsdk:"displayname"`
+	Mail        types.List   `tfsdk:"mail"`
+}
+
+func (d *personsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_persons"
+}
+
+func (d *personsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	attrs := searchFilterAttributes(true)
+	attrs["ids"] = schema.ListAttribute{
+		MarkdownDescription: "Names of the matching person accounts, in the order returned by Kanidm.",
+		Computed:            true,
+		ElementType:         types.StringType,
+	}
+	attrs["persons"] = schema.ListNestedAttribute{
+		MarkdownDescription: "The matching person accounts.",
+		Computed:            true,
+		NestedObject: schema.NestedAttributeObject{
+			Attributes: map[string]schema.Attribute{
+				"id": schema.StringAttribute{
+					MarkdownDescription: "Name of the person account.",
+					Computed:            true,
+				},
+				"uuid": schema.StringAttribute{
+					MarkdownDescription: "Immutable UUID of the person account.",
+					Computed:            true,
+				},
+				"spn": schema.StringAttribute{
+					MarkdownDescription: "Security principal name of the person account.",
+					Computed:            true,
+				},
+				"displayname": schema.StringAttribute{
+					MarkdownDescription: "Display name of the person.",
+					Computed:            true,
+				},
+				"mail": schema.ListAttribute{
+					MarkdownDescription: "Email addresses for the person.",
+					Computed:            true,
+					ElementType:         types.StringType,
+				},
+			},
+		},
+	}
+
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Lists Kanidm person accounts, optionally filtered.
+
+## Example Usage
+
+` + "```hcl" + `
+data "kanidm_persons" "developers" {
+  member_of = "developers"
+}
+
+resource "github_membership" "developers" {
+  for_each = toset(data.kanidm_persons.developers.ids)
+  username = each.value
+}
+` + "```",
+		Attributes: attrs,
+	}
+}
+
+func (d *personsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *client.Client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	d.client = c
+}
+
+func (d *personsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state personsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter, diags := buildSearchFilter(state.MemberOf, state.Class, state.Filter)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Listing persons", map[string]any{
+		"filter": filter,
+	})
+
+	var persons []*client.Person
+	var err error
+	if filter == nil {
+		persons, err = d.client.ListPersons(ctx)
+	} else {
+		persons, err = d.client.SearchPersons(ctx, filter)
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing Persons",
+			"Could not list persons: "+err.Error(),
+		)
+		return
+	}
+
+	ids := make([]string, 0, len(persons))
+	state.Persons = make([]personSummaryModel, 0, len(persons))
+	for _, person := range persons {
+		if !matchesNamePrefix(person.ID, state.NamePrefix) {
+			continue
+		}
+
+		mail := person.Mail
+		if mail == nil {
+			mail = []string{}
+		}
+		mailList, diags := types.ListValueFrom(ctx, types.StringType, mail)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		ids = append(ids, person.ID)
+		state.Persons = append(state.Persons, personSummaryModel{
+			ID:          types.StringValue(person.ID),
+			UUID:        types.StringValue(person.UUID),
+			SPN:         types.StringValue(person.SPN),
+			DisplayName: types.StringValue(person.DisplayName),
+			Mail:        mailList,
+		})
+	}
+
+	idList, diags := types.ListValueFrom(ctx, types.StringType, ids)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.IDs = idList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
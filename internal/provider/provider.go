@@ -2,9 +2,17 @@ package provider
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -15,7 +23,10 @@ import (
 )
 
 // Ensure the implementation satisfies the provider.Provider interface
-var _ provider.Provider = (*kanidmProvider)(nil)
+var (
+	_ provider.Provider                       = (*kanidmProvider)(nil)
+	_ provider.ProviderWithEphemeralResources = (*kanidmProvider)(nil)
+)
 
 // kanidmProvider is the provider implementation
 type kanidmProvider struct {
@@ -24,8 +35,11 @@ type kanidmProvider struct {
 
 // kanidmProviderModel describes the provider data model
 type kanidmProviderModel struct {
-	URL   types.String `tfsdk:"url"`
-	Token types.String `tfsdk:"token"`
+	URL                       types.String `tfsdk:"url"`
+	Token                     types.String `tfsdk:"token"`
+	SkipCredentialsValidation types.Bool   `tfsdk:"skip_credentials_validation"`
+	DeletionMode              types.String `tfsdk:"deletion_mode"`
+	ProtectedNames            types.List   `tfsdk:"protected_names"`
 }
 
 // New creates a new provider instance
@@ -57,6 +71,27 @@ func (p *kanidmProvider) Schema(_ context.Context, _ provider.SchemaRequest, res
 				Optional:    true,
 				Sensitive:   true,
 			},
+			"skip_credentials_validation": schema.BoolAttribute{
+				Description: "Skip checking the server status and token validity when the provider is configured. " +
+					"May also be provided via KANIDM_SKIP_CREDENTIALS_VALIDATION environment variable. Defaults to false.",
+				Optional: true,
+			},
+			"deletion_mode": schema.StringAttribute{
+				Description: "How entries left in the Kanidm recycle bin are treated. With \"recycle\", creating a person, " +
+					"group or service account revives a recycled entry with the same name, keeping its UUID, credentials " +
+					"and group memberships. With \"purge\", recycled entries are ignored and a new entry is always created. " +
+					"Destroy never purges: Kanidm has no API to purge recycled entries early, so in either mode a destroyed " +
+					"entry stays in the recycle bin until its retention period passes. Do not rely on terraform destroy " +
+					"to free a name. May also be provided via KANIDM_DELETION_MODE environment variable. Defaults to \"purge\".",
+				Optional: true,
+			},
+			"protected_names": schema.ListAttribute{
+				Description: "Names or UUIDs of entries the provider refuses to delete or replace, whatever their " +
+					"deletion_protection setting, e.g. idm_admins or break-glass accounts. May also be provided via " +
+					"KANIDM_PROTECTED_NAMES environment variable as a comma-separated list.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
 		},
 	}
 }
@@ -103,30 +138,189 @@ func (p *kanidmProvider) Configure(ctx context.Context, req provider.ConfigureRe
 		)
 	}
 
+	// Resolve deletion mode from configuration or environment variable
+	deletionMode := client.DeletionModePurge
+	rawDeletionMode := os.Getenv("KANIDM_DELETION_MODE")
+	if !config.DeletionMode.IsNull() {
+		rawDeletionMode = config.DeletionMode.ValueString()
+	}
+
+	if rawDeletionMode != "" {
+		mode, err := client.ParseDeletionMode(rawDeletionMode)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("deletion_mode"),
+				"Invalid Deletion Mode",
+				"The deletion_mode must be \"recycle\" or \"purge\": "+err.Error(),
+			)
+		}
+		deletionMode = mode
+	}
+
+	// Resolve protected names from configuration or environment variable
+	var protectedNames []string
+	if !config.ProtectedNames.IsNull() {
+		elementsAs(ctx, config.ProtectedNames, &protectedNames, &resp.Diagnostics)
+	} else if raw := os.Getenv("KANIDM_PROTECTED_NAMES"); raw != "" {
+		for _, name := range strings.Split(raw, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				protectedNames = append(protectedNames, name)
+			}
+		}
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	// Create Kanidm client
 	tflog.Debug(ctx, "Creating Kanidm client", map[string]any{
-		"url": url,
+		"url":             url,
+		"deletion_mode":   deletionMode,
+		"protected_names": protectedNames,
 	})
 
-	apiClient := client.NewClient(url, token)
+	apiClient := client.NewClient(url, token, client.WithDeletionMode(deletionMode), client.WithProtectedNames(protectedNames))
+
+	// Resolve credential validation opt-out from configuration or environment variable
+	skipValidation := false
+	if v, err := strconv.ParseBool(os.Getenv("KANIDM_SKIP_CREDENTIALS_VALIDATION")); err == nil {
+		skipValidation = v
+	}
+	if !config.SkipCredentialsValidation.IsNull() {
+		skipValidation = config.SkipCredentialsValidation.ValueBool()
+	}
+
+	if !skipValidation {
+		validateConnection(ctx, apiClient, url, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
 
 	// Make the client available to data sources and resources
 	resp.DataSourceData = apiClient
 	resp.ResourceData = apiClient
+	resp.EphemeralResourceData = apiClient
 
 	tflog.Info(ctx, "Configured Kanidm client", map[string]any{
-		"success": true,
+		"success":        true,
+		"server_version": apiClient.ServerVersion(),
 	})
 }
 
+// validateConnection checks that the Kanidm server is reachable and that the
+// token is valid and able to make changes
+func validateConnection(ctx context.Context, apiClient *client.Client, url string, diags *diag.Diagnostics) {
+	tflog.Debug(ctx, "Validating Kanidm server and credentials")
+
+	status, err := apiClient.GetStatus(ctx)
+	if err != nil {
+		diags.AddAttributeError(path.Root("url"), "Unable to Reach Kanidm Server", connectionErrorDetail(url, err))
+		return
+	}
+
+	if !status.Healthy {
+		diags.AddAttributeError(
+			path.Root("url"),
+			"Kanidm Server Unhealthy",
+			"The Kanidm server at "+url+" reported that it is not healthy. Check the server logs and try again.",
+		)
+		return
+	}
+
+	// Servers that do not report a version are left for the API to reject
+	if version, err := client.ParseVersion(status.Version); err == nil && !version.AtLeast(client.MinServerVersion) {
+		diags.AddAttributeError(
+			path.Root("url"),
+			"Unsupported Kanidm Version",
+			"The Kanidm server at "+url+" is running "+version.String()+", but the provider requires Kanidm >= "+
+				client.MinServerVersion.String()+". Upgrade the server and try again.",
+		)
+		return
+	}
+
+	whoami, err := apiClient.Whoami(ctx)
+	if err != nil {
+		switch {
+		case errors.Is(err, client.ErrUnauthorized):
+			diags.AddAttributeError(
+				path.Root("token"),
+				"Invalid Kanidm Token",
+				"The Kanidm server rejected the API token. It may be expired, revoked, or issued by a different server. "+
+					"Generate a new token with: kanidm service-account api-token generate <account> <label> --readwrite\n\n"+err.Error(),
+			)
+		case errors.Is(err, client.ErrForbidden):
+			diags.AddAttributeError(
+				path.Root("token"),
+				"Insufficient Kanidm Privileges",
+				"The API token is valid but is not permitted to read its own entry: "+err.Error(),
+			)
+		default:
+			diags.AddError("Unable to Validate Kanidm Token", "Could not read the identity of the API token: "+err.Error())
+		}
+		return
+	}
+
+	uat, err := apiClient.GetUserAuthToken(ctx)
+	if err != nil {
+		diags.AddError("Unable to Validate Kanidm Token", "Could not read the session of the API token: "+err.Error())
+		return
+	}
+
+	if !uat.ReadWrite {
+		diags.AddAttributeError(
+			path.Root("token"),
+			"Read-Only Kanidm Token",
+			"The API token for "+whoami.SPN+" is read-only, so the provider cannot create or modify entries. "+
+				"Generate a token with the --readwrite flag, or set skip_credentials_validation if only data sources are used.",
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Validated Kanidm credentials", map[string]any{
+		"spn":            whoami.SPN,
+		"server_version": status.Version,
+	})
+}
+
+// connectionErrorDetail explains why the Kanidm server could not be reached
+func connectionErrorDetail(url string, err error) string {
+	var (
+		certErr      *tls.CertificateVerificationError
+		unknownCAErr x509.UnknownAuthorityError
+		hostnameErr  x509.HostnameError
+		dnsErr       *net.DNSError
+		opErr        *net.OpError
+	)
+
+	switch {
+	case errors.As(err, &certErr), errors.As(err, &unknownCAErr), errors.As(err, &hostnameErr):
+		return "The TLS certificate presented by " + url + " could not be verified. " +
+			"Check that the URL matches the certificate and that the issuing CA is trusted.\n\n" + err.Error()
+	case errors.As(err, &dnsErr):
+		return "The host in " + url + " could not be resolved. Check the url value.\n\n" + err.Error()
+	case errors.As(err, &opErr):
+		return "Could not connect to " + url + ". Check that the server is running and reachable.\n\n" + err.Error()
+	case errors.Is(err, client.ErrNotFound):
+		return "The status endpoint was not found at " + url + ". Check that the url points at a Kanidm server.\n\n" + err.Error()
+	default:
+		return "Could not check the status of " + url + ": " + err.Error()
+	}
+}
+
 // DataSources defines the data sources implemented in the provider
 func (p *kanidmProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
-		// Data sources will be implemented later
+		NewPersonDataSource,
+		NewGroupDataSource,
+		NewGroupEffectiveMembersDataSource,
+		NewServiceAccountDataSource,
+		NewPersonsDataSource,
+		NewGroupsDataSource,
+		NewOAuth2ClientsDataSource,
+		NewRecycleBinDataSource,
+		NewRadiusTokenDataSource,
 	}
 }
 
@@ -137,5 +331,23 @@ func (p *kanidmProvider) Resources(_ context.Context) []func() resource.Resource
 		NewServiceAccountResource,
 		NewGroupResource,
 		NewOAuth2BasicResource,
+		NewEntryAttributesResource,
+		NewDomainResource,
+		NewSystemPasswordBadlistResource,
+		NewSystemDeniedNamesResource,
+		NewKeyObjectRotationResource,
+		NewSyncAccountResource,
+		NewApplicationResource,
+		NewApplicationPasswordResource,
+		NewRadiusCredentialResource,
+	}
+}
+
+// EphemeralResources defines the ephemeral resources implemented in the provider
+func (p *kanidmProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return []func() ephemeral.EphemeralResource{
+		NewPersonCredentialResetTokenEphemeralResource,
+		NewServiceAccountAPITokenEphemeralResource,
+		NewOAuth2BasicSecretEphemeralResource,
 	}
 }
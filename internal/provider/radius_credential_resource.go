@@ -0,0 +1,259 @@
+package provider
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ssoriche/terraform-provider-kanidm/internal/client"
+)
+
+// Ensure the implementation satisfies the required interfaces
+var (
+	_ resource.Resource                = (*radiusCredentialResource)(nil)
+	_ resource.ResourceWithImportState = (*radiusCredentialResource)(nil)
+	_ resource.ResourceWithModifyPlan  = (*radiusCredentialResource)(nil)
+)
+
+// NewRadiusCredentialResource creates a new RADIUS credential resource
+func NewRadiusCredentialResource() resource.Resource {
+	return &radiusCredentialResource{}
+}
+
+// radiusCredentialResource is the resource implementation
+type radiusCredentialResource struct {
+	client *client.Client
+}
+
+// radiusCredentialResourceModel describes the resource data model
+type radiusCredentialResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	SecretVersion      types.Int64  `tfsdk:"secret_version"`
+	Secret             types.String `tfsdk:"secret"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
+}
+
+// Metadata returns the resource type name
+func (r *radiusCredentialResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_radius_credential"
+}
+
+// Schema defines the schema for the resource
+func (r *radiusCredentialResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Generates the RADIUS secret of a Kanidm person account.
+
+Devices use the secret to authenticate to Wi-Fi and other networks through Kanidm's RADIUS
+integration. The VLAN an account is placed in is chosen by the RADIUS server from the account's
+groups; see the ` + "`kanidm_radius_token`" + ` data source. Destroying the resource removes the secret.
+
+## Example Usage
+
+` + "```hcl" + `
+resource "kanidm_radius_credential" "alice" {
+  id = kanidm_person.alice.id
+
+  # Bump to generate a new secret
+  secret_version = 1
+}
+
+output "alice_radius_secret" {
+  value     = kanidm_radius_credential.alice.secret
+  sensitive = true
+}
+` + "```",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Name, SPN or UUID of the person account.",
+				Required:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"secret_version": schema.Int64Attribute{
+				MarkdownDescription: "Change this value to generate a new secret, e.g. after a device is lost.",
+				Optional:            true,
+			},
+			"secret": schema.StringAttribute{
+				MarkdownDescription: "The RADIUS secret. It is stored in state.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"deletion_protection": deletionProtectionAttribute(),
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource
+func (r *radiusCredentialResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			"Expected *client.Client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	r.client = c
+}
+
+// ModifyPlan refuses to plan the destruction or replacement of a protected RADIUS secret
+func (r *radiusCredentialResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPlanProtection(ctx, r.client, req, resp, nil, path.Root("id"))
+}
+
+// Create generates the RADIUS secret
+func (r *radiusCredentialResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan radiusCredentialResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Generating RADIUS secret", map[string]any{
+		"id": plan.ID.ValueString(),
+	})
+
+	secret, err := r.client.GeneratePersonRadiusSecret(ctx, plan.ID.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error Generating RADIUS Secret", "Could not generate RADIUS secret: ", err, map[string]path.Path{
+			client.ErrCodeNoMatchingEntries: path.Root("id"),
+		})
+		return
+	}
+
+	plan.Secret = types.StringValue(secret)
+
+	tflog.Debug(ctx, "RADIUS secret generated successfully", map[string]any{
+		"id": plan.ID.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the secret, so one regenerated outside Terraform is picked up
+func (r *radiusCredentialResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state radiusCredentialResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading RADIUS secret", map[string]any{
+		"id": state.ID.ValueString(),
+	})
+
+	secret, err := r.client.GetPersonRadiusSecret(ctx, state.ID.ValueString())
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			tflog.Warn(ctx, "RADIUS secret not found, removing from state", map[string]any{
+				"id": state.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Reading RADIUS Secret",
+			"Could not read RADIUS secret: "+err.Error(),
+		)
+		return
+	}
+
+	state.Secret = types.StringValue(secret)
+	state.DeletionProtection = deletionProtectionValue(state.DeletionProtection)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update generates a new secret when secret_version changes
+func (r *radiusCredentialResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state radiusCredentialResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Updating RADIUS secret", map[string]any{
+		"id": plan.ID.ValueString(),
+	})
+
+	plan.Secret = state.Secret
+	if !plan.SecretVersion.Equal(state.SecretVersion) {
+		secret, err := r.client.GeneratePersonRadiusSecret(ctx, plan.ID.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Generating RADIUS Secret",
+				"Could not generate a new RADIUS secret: "+err.Error(),
+			)
+			return
+		}
+		plan.Secret = types.StringValue(secret)
+	}
+
+	tflog.Debug(ctx, "RADIUS secret updated successfully", map[string]any{
+		"id": plan.ID.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete removes the RADIUS secret
+func (r *radiusCredentialResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state radiusCredentialResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Deleting RADIUS secret", map[string]any{
+		"id": state.ID.ValueString(),
+	})
+
+	checkDeletionProtection(ctx, r.client, req.State, nil, "destroyed", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeletePersonRadiusSecret(ctx, state.ID.ValueString()); err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			tflog.Warn(ctx, "Person not found during delete, removing RADIUS secret from state", map[string]any{
+				"id": state.ID.ValueString(),
+			})
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Deleting RADIUS Secret",
+			"Could not delete RADIUS secret: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "RADIUS secret deleted successfully", map[string]any{
+		"id": state.ID.ValueString(),
+	})
+}
+
+// ImportState imports the existing RADIUS secret of a person
+func (r *radiusCredentialResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+
+	tflog.Debug(ctx, "Imported RADIUS secret", map[string]any{
+		"id": req.ID,
+	})
+}
@@ -0,0 +1,155 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ssoriche/terraform-provider-kanidm/internal/client"
+)
+
+var _ datasource.DataSourceWithConfigure = (*radiusTokenDataSource)(nil)
+
+func NewRadiusTokenDataSource() datasource.DataSource {
+	return &radiusTokenDataSource{}
+}
+
+type radiusTokenDataSource struct {
+	client *client.Client
+}
+
+type radiusTokenDataSourceModel struct {
+	ID          types.String            `tfsdk:"id"`
+	Name        types.String            `tfsdk:"name"`
+	DisplayName types.String            `tfsdk:"displayname"`
+	UUID        types.String            `tfsdk:"uuid"`
+	Secret      types.String            `tfsdk:"secret"`
+	Groups      []radiusTokenGroupModel `tfsdk:"groups"`
+}
+
+type radiusTokenGroupModel struct {
+	SPN  types.String `tfsdk:"spn"`
+	UUID types.String `tfsdk:"uuid"`
+}
+
+func (d *radiusTokenDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_radius_token"
+}
+
+func (d *radiusTokenDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Reads the effective RADIUS token of a person or service account.
+
+The token holds the RADIUS secret and every group the account belongs to, including through
+nested groups, as the RADIUS server sees them when choosing a VLAN. The account must have a
+RADIUS secret, e.g. from ` + "`kanidm_radius_credential`" + `.
+
+## Example Usage
+
+` + "```hcl" + `
+data "kanidm_radius_token" "alice" {
+  id = kanidm_radius_credential.alice.id
+}
+
+locals {
+  alice_vlan = contains(data.kanidm_radius_token.alice.groups[*].spn, "staff@idm.example.com") ? 10 : 20
+}
+` + "```",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Name, SPN or UUID of the account.",
+				Required:            true,
+			},
+			"name": schema.StringAttribute{
+				MarkdownDescription: "Name of the account.",
+				Computed:            true,
+			},
+			"displayname": schema.StringAttribute{
+				MarkdownDescription: "Display name of the account.",
+				Computed:            true,
+			},
+			"uuid": schema.StringAttribute{
+				MarkdownDescription: "Immutable UUID of the account.",
+				Computed:            true,
+			},
+			"secret": schema.StringAttribute{
+				MarkdownDescription: "The RADIUS secret of the account.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"groups": schema.ListNestedAttribute{
+				MarkdownDescription: "Groups the account belongs to, directly or through nested groups.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"spn": schema.StringAttribute{
+							MarkdownDescription: "Security principal name of the group.",
+							Computed:            true,
+						},
+						"uuid": schema.StringAttribute{
+							MarkdownDescription: "Immutable UUID of the group.",
+							Computed:            true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *radiusTokenDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *client.Client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	d.client = c
+}
+
+func (d *radiusTokenDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state radiusTokenDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading RADIUS token", map[string]any{
+		"id": state.ID.ValueString(),
+	})
+
+	token, err := d.client.GetRadiusToken(ctx, state.ID.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error Reading RADIUS Token", "Could not read RADIUS token. The account must have a RADIUS secret: ", err, map[string]path.Path{
+			client.ErrCodeNoMatchingEntries: path.Root("id"),
+			client.ErrCodeMissingAttribute:  path.Root("id"),
+		})
+		return
+	}
+
+	state.Name = types.StringValue(token.Name)
+	state.DisplayName = types.StringValue(token.DisplayName)
+	state.UUID = types.StringValue(token.UUID)
+	state.Secret = types.StringValue(token.Secret)
+
+	state.Groups = make([]radiusTokenGroupModel, 0, len(token.Groups))
+	for _, group := range token.Groups {
+		state.Groups = append(state.Groups, radiusTokenGroupModel{
+			SPN:  types.StringValue(group.SPN),
+			UUID: types.StringValue(group.UUID),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
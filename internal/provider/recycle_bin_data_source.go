@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ssoriche/terraform-provider-kanidm/internal/client"
+)
+
+var _ datasource.DataSourceWithConfigure = (*recycleBinDataSource)(nil)
+
+func NewRecycleBinDataSource() datasource.DataSource {
+	return &recycleBinDataSource{}
+}
+
+type recycleBinDataSource struct {
+	client *client.Client
+}
+
+type recycleBinDataSourceModel struct {
+	Kind       types.String                `tfsdk:"kind"`
+	NamePrefix types.String                `tfsdk:"name_prefix"`
+	Names      types.List                  `tfsdk:"names"`
+	Entries    []recycledEntrySummaryModel `tfsdk:"entries"`
+}
+
+type recycledEntrySummaryModel struct {
+	Name    types.String `tfsdk:"name"`
+	UUID    types.String `tfsdk:"uuid"`
+	SPN     types.String `tfsdk:"spn"`
+	Kind    types.String `tfsdk:"kind"`
+	Classes types.Set    `tfsdk:"classes"`
+}
+
+func (d *recycleBinDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_recycle_bin"
+}
+
+func (d *recycleBinDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Lists entries in the Kanidm recycle bin.
+
+Deleted entries stay in the recycle bin until its retention period passes. With the provider
+` + "`deletion_mode = \"recycle\"`" + `, creating a person, group or service account with the name of a
+recycled entry revives it instead.
+
+## Example Usage
+
+` + "```hcl" + `
+data "kanidm_recycle_bin" "persons" {
+  kind = "person"
+}
+
+output "recycled_persons" {
+  value = data.kanidm_recycle_bin.persons.names
+}
+` + "```",
+		Attributes: map[string]schema.Attribute{
+			"kind": schema.StringAttribute{
+				MarkdownDescription: "Only list entries of this kind: `person`, `group`, `service_account` or `oauth2`.",
+				Optional:            true,
+			},
+			"name_prefix": schema.StringAttribute{
+				MarkdownDescription: "Only list entries whose name starts with this prefix.",
+				Optional:            true,
+			},
+			"names": schema.ListAttribute{
+				MarkdownDescription: "Names of the matching recycled entries, in the order returned by Kanidm.",
+				Computed:            true,
+				ElementType:         types.StringType,
+			},
+			"entries": schema.ListNestedAttribute{
+				MarkdownDescription: "The matching recycled entries.",
+				Computed:            true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"name": schema.StringAttribute{
+							MarkdownDescription: "Name of the entry.",
+							Computed:            true,
+						},
+						"uuid": schema.StringAttribute{
+							MarkdownDescription: "Immutable UUID of the entry, kept when it is revived.",
+							Computed:            true,
+						},
+						"spn": schema.StringAttribute{
+							MarkdownDescription: "Security principal name of the entry.",
+							Computed:            true,
+						},
+						"kind": schema.StringAttribute{
+							MarkdownDescription: "Kind of the entry, or an empty string for entries the provider does not manage.",
+							Computed:            true,
+						},
+						"classes": schema.SetAttribute{
+							MarkdownDescription: "Object classes of the entry.",
+							Computed:            true,
+							ElementType:         types.StringType,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (d *recycleBinDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *client.Client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	d.client = c
+}
+
+func (d *recycleBinDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state recycleBinDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var kind client.EntryKind
+	if !state.Kind.IsNull() {
+		var err error
+		kind, err = client.ParseEntryKind(state.Kind.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("kind"), "Invalid Entry Kind", err.Error())
+			return
+		}
+	}
+
+	tflog.Debug(ctx, "Listing recycle bin", map[string]any{
+		"kind": kind,
+	})
+
+	entries, err := d.client.ListRecycledEntries(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Listing Recycle Bin",
+			"Could not list recycled entries: "+err.Error(),
+		)
+		return
+	}
+
+	names := make([]string, 0, len(entries))
+	state.Entries = make([]recycledEntrySummaryModel, 0, len(entries))
+	for _, entry := range entries {
+		if kind != "" && entry.Kind != kind {
+			continue
+		}
+		if !matchesNamePrefix(entry.Name, state.NamePrefix) {
+			continue
+		}
+
+		entryClasses := entry.Classes
+		if entryClasses == nil {
+			entryClasses = []string{}
+		}
+		classes, diags := types.SetValueFrom(ctx, types.StringType, entryClasses)
+		resp.Diagnostics.Append(diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		names = append(names, entry.Name)
+		state.Entries = append(state.Entries, recycledEntrySummaryModel{
+			Name:    types.StringValue(entry.Name),
+			UUID:    types.StringValue(entry.UUID),
+			SPN:     types.StringValue(entry.SPN),
+			Kind:    types.StringValue(string(entry.Kind)),
+			Classes: classes,
+		})
+	}
+
+	namesList, diags := types.ListValueFrom(ctx, types.StringType, names)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.Names = namesList
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -0,0 +1,74 @@
+package provider
+
+import (
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/ssoriche/terraform-provider-kanidm/internal/client"
+)
+
+// searchFilterAttributes returns the filter arguments shared by the plural data sources
+func searchFilterAttributes(withMemberOf bool) map[string]schema.Attribute {
+	attrs := map[string]schema.Attribute{
+		"name_prefix": schema.StringAttribute{
+			MarkdownDescription: "Only return entries whose name starts with this prefix.",
+			Optional:            true,
+		},
+		"class": schema.StringAttribute{
+			MarkdownDescription: "Only return entries that have this class (e.g., `posixaccount`).",
+			Optional:            true,
+		},
+		"filter": schema.StringAttribute{
+			MarkdownDescription: "Raw Kanidm filter in JSON protocol form, combined with the other arguments using `and`. " +
+				"For example `{\"pres\": \"mail\"}` or `{\"or\": [{\"eq\": [\"name\", \"alice\"]}, {\"eq\": [\"name\", \"bob\"]}]}`.",
+			Optional: true,
+		},
+	}
+
+	if withMemberOf {
+		attrs["member_of"] = schema.StringAttribute{
+			MarkdownDescription: "Only return entries that are members of this group, directly or through nested groups.",
+			Optional:            true,
+		}
+	}
+
+	return attrs
+}
+
+// buildSearchFilter combines the filter arguments into a server-side filter.
+// It returns nil when no server-side filtering was requested.
+func buildSearchFilter(memberOf, class, raw types.String) (client.Filter, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	var filters []client.Filter
+
+	if !memberOf.IsNull() && memberOf.ValueString() != "" {
+		filters = append(filters, client.FilterEq("memberof", memberOf.ValueString()))
+	}
+
+	if !class.IsNull() && class.ValueString() != "" {
+		filters = append(filters, client.FilterEq("class", class.ValueString()))
+	}
+
+	if !raw.IsNull() && raw.ValueString() != "" {
+		filter, err := client.ParseFilter(raw.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("filter"), "Invalid Filter", err.Error())
+			return nil, diags
+		}
+		filters = append(filters, filter)
+	}
+
+	return client.FilterAnd(filters...), diags
+}
+
+// matchesNamePrefix reports whether name satisfies the optional name_prefix argument.
+// Kanidm filters have no prefix operator, so this is applied client-side.
+func matchesNamePrefix(name string, prefix types.String) bool {
+	if prefix.IsNull() {
+		return true
+	}
+	return strings.HasPrefix(name, prefix.ValueString())
+}
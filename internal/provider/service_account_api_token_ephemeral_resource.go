@@ -0,0 +1,232 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ssoriche/terraform-provider-kanidm/internal/client"
+)
+
+// Ensure the implementation satisfies the required interfaces
+var (
+	_ ephemeral.EphemeralResource              = (*serviceAccountAPITokenEphemeralResource)(nil)
+	_ ephemeral.EphemeralResourceWithConfigure = (*serviceAccountAPITokenEphemeralResource)(nil)
+	_ ephemeral.EphemeralResourceWithClose     = (*serviceAccountAPITokenEphemeralResource)(nil)
+)
+
+const (
+	// defaultAPITokenTTL is the lifetime of ephemeral API tokens in seconds
+	defaultAPITokenTTL = 3600
+	// defaultAPITokenLabel identifies ephemeral API tokens on the service account
+	defaultAPITokenLabel = "terraform-ephemeral"
+	// apiTokenPrivateKey is the private state key holding the token to revoke on close
+	apiTokenPrivateKey = "api_token"
+)
+
+// NewServiceAccountAPITokenEphemeralResource creates a new service account API token ephemeral resource
+func NewServiceAccountAPITokenEphemeralResource() ephemeral.EphemeralResource {
+	return &serviceAccountAPITokenEphemeralResource{}
+}
+
+// serviceAccountAPITokenEphemeralResource is the ephemeral resource implementation
+type serviceAccountAPITokenEphemeralResource struct {
+	client *client.Client
+}
+
+// serviceAccountAPITokenEphemeralResourceModel describes the ephemeral resource data model
+type serviceAccountAPITokenEphemeralResourceModel struct {
+	ID            types.String `tfsdk:"id"`
+	Label         types.String `tfsdk:"label"`
+	TTL           types.Int64  `tfsdk:"ttl"`
+	ReadWrite     types.Bool   `tfsdk:"read_write"`
+	RevokeOnClose types.Bool   `tfsdk:"revoke_on_close"`
+	Token         types.String `tfsdk:"token"`
+	ExpiryTime    types.String `tfsdk:"expiry_time"`
+}
+
+// apiTokenPrivate identifies a minted token in private state so Close can revoke it
+type apiTokenPrivate struct {
+	ID      string `json:"id"`
+	TokenID string `json:"token_id"`
+}
+
+// Metadata returns the ephemeral resource type name
+func (r *serviceAccountAPITokenEphemeralResource) Metadata(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service_account_api_token"
+}
+
+// Schema defines the schema for the ephemeral resource
+func (r *serviceAccountAPITokenEphemeralResource) Schema(_ context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Mints a short-lived API token for a service account without storing it in state (Terraform 1.10+).
+
+A new token is minted every time the ephemeral resource is opened, which happens during both plan
+and apply. Each token is revoked when Terraform is done with it, so it is only usable by providers
+and provisioners within the same run. To hand a token to something outside Terraform, such as a
+write-only argument, set ` + "`revoke_on_close = false`" + `; the token is then left to expire, as is
+any token minted during plan.
+
+## Example Usage
+
+` + "```hcl" + `
+ephemeral "kanidm_service_account_api_token" "ci" {
+  id         = kanidm_service_account.ci.id
+  label      = "ci-pipeline"
+  ttl        = 86400 # 24 hours
+  read_write = false
+
+  # The token is stored outside Terraform, so leave it to expire instead
+  revoke_on_close = false
+}
+
+resource "kubernetes_secret_v1" "ci" {
+  metadata {
+    name      = "kanidm-ci"
+    namespace = "ci"
+  }
+
+  data_wo = {
+    token = ephemeral.kanidm_service_account_api_token.ci.token
+  }
+  data_wo_revision = 1
+}
+` + "```",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Name, SPN or UUID of the service account.",
+				Required:            true,
+			},
+			"label": schema.StringAttribute{
+				MarkdownDescription: "Label of the token, shown when listing the service account's tokens. Defaults to `terraform-ephemeral`.",
+				Optional:            true,
+			},
+			"ttl": schema.Int64Attribute{
+				MarkdownDescription: "Time-to-live for the token in seconds. Defaults to 3600 (1 hour).",
+				Optional:            true,
+			},
+			"read_write": schema.BoolAttribute{
+				MarkdownDescription: "Whether the token may make changes, rather than only read. Defaults to `false`.",
+				Optional:            true,
+			},
+			"revoke_on_close": schema.BoolAttribute{
+				MarkdownDescription: "Whether to revoke the token when Terraform is done with it. Defaults to `true`.",
+				Optional:            true,
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "The API token.",
+				Computed:            true,
+				Sensitive:           true,
+			},
+			"expiry_time": schema.StringAttribute{
+				MarkdownDescription: "RFC 3339 time after which the token can no longer be used.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the ephemeral resource
+func (r *serviceAccountAPITokenEphemeralResource) Configure(_ context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Ephemeral Resource Configure Type",
+			"Expected *client.Client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	r.client = c
+}
+
+// Open mints a new API token
+func (r *serviceAccountAPITokenEphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var data serviceAccountAPITokenEphemeralResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Generating service account API token", map[string]any{
+		"id": data.ID.ValueString(),
+	})
+
+	label := defaultAPITokenLabel
+	if !data.Label.IsNull() {
+		label = data.Label.ValueString()
+	}
+
+	ttl := int64(defaultAPITokenTTL)
+	if !data.TTL.IsNull() {
+		ttl = data.TTL.ValueInt64()
+	}
+	expiry := time.Now().Add(time.Duration(ttl) * time.Second).UTC().Truncate(time.Second)
+
+	token, err := r.client.GenerateServiceAccountToken(ctx, data.ID.ValueString(), label, &expiry, data.ReadWrite.ValueBool())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error Generating API Token", "Could not generate service account API token: ", err, map[string]path.Path{
+			client.ErrCodeNoMatchingEntries: path.Root("id"),
+		})
+		return
+	}
+
+	data.Token = types.StringValue(token)
+	data.ExpiryTime = types.StringValue(expiry.Format(time.RFC3339))
+
+	resp.Diagnostics.Append(resp.Result.Set(ctx, &data)...)
+
+	if !data.RevokeOnClose.IsNull() && !data.RevokeOnClose.ValueBool() {
+		return
+	}
+
+	tokenID, err := client.APITokenID(token)
+	if err != nil {
+		resp.Diagnostics.AddWarning(
+			"API Token Will Not Be Revoked",
+			"Could not read the ID of the minted token, so it will remain valid until "+data.ExpiryTime.ValueString()+": "+err.Error(),
+		)
+		return
+	}
+
+	private, err := json.Marshal(apiTokenPrivate{ID: data.ID.ValueString(), TokenID: tokenID})
+	if err != nil {
+		resp.Diagnostics.AddError("Error Saving API Token", "Could not encode the token to revoke: "+err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.Private.SetKey(ctx, apiTokenPrivateKey, private)...)
+}
+
+// Close revokes the token minted by Open
+func (r *serviceAccountAPITokenEphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	raw, diags := req.Private.GetKey(ctx, apiTokenPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || raw == nil {
+		return
+	}
+
+	var private apiTokenPrivate
+	if err := json.Unmarshal(raw, &private); err != nil {
+		resp.Diagnostics.AddError("Error Revoking API Token", "Could not decode the token to revoke: "+err.Error())
+		return
+	}
+
+	tflog.Debug(ctx, "Revoking service account API token", map[string]any{
+		"id":       private.ID,
+		"token_id": private.TokenID,
+	})
+
+	if err := r.client.DestroyServiceAccountToken(ctx, private.ID, private.TokenID); err != nil {
+		addAPIError(&resp.Diagnostics, "Error Revoking API Token", "Could not revoke service account API token: ", err, nil)
+	}
+}
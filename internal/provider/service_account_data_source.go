@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ssoriche/terraform-provider-kanidm/internal/client"
+)
+
+var _ datasource.DataSourceWithConfigure = (*serviceAccountDataSource)(nil)
+
+func NewServiceAccountDataSource() datasource.DataSource {
+	return &serviceAccountDataSource{}
+}
+
+type serviceAccountDataSource struct {
+	client *client.Client
+}
+
+type serviceAccountDataSourceModel struct {
+	ID     types.String `tfsdk:"id"`
+	UUID   types.String `tfsdk:"uuid"`
+	SPN    types.String `tfsdk:"spn"`
+	Exists types.Bool   `tfsdk:"exists"`
+}
+
+func (d *serviceAccountDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_service_account"
+}
+
+func (d *serviceAccountDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Looks up an existing Kanidm service account.
+
+Unlike the other lookups, a missing service account is not an error: ` + "`exists`" + ` is set to
+` + "`false`" + ` so configurations can branch on whether the account is present.
+
+## Example Usage
+
+` + "```hcl" + `
+data "kanidm_service_account" "ci" {
+  id = "ci"
+}
+
+output "ci_exists" {
+  value = data.kanidm_service_account.ci.exists
+}
+` + "```",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Name, SPN or UUID of the service account to look up.",
+				Required:            true,
+			},
+			"uuid": schema.StringAttribute{
+				MarkdownDescription: "Immutable UUID of the service account. Null if it does not exist.",
+				Computed:            true,
+			},
+			"spn": schema.StringAttribute{
+				MarkdownDescription: "Security principal name of the service account. Null if it does not exist.",
+				Computed:            true,
+			},
+			"exists": schema.BoolAttribute{
+				MarkdownDescription: "Whether the service account exists.",
+				Computed:            true,
+			},
+		},
+	}
+}
+
+func (d *serviceAccountDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			"Expected *client.Client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	d.client = c
+}
+
+func (d *serviceAccountDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config serviceAccountDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading service account data source", map[string]any{
+		"id": config.ID.ValueString(),
+	})
+
+	state := serviceAccountDataSourceModel{
+		ID:     config.ID,
+		UUID:   types.StringNull(),
+		SPN:    types.StringNull(),
+		Exists: types.BoolValue(false),
+	}
+
+	sa, err := d.client.GetServiceAccount(ctx, config.ID.ValueString())
+	if err != nil {
+		if !errors.Is(err, client.ErrNotFound) {
+			resp.Diagnostics.AddError(
+				"Error Reading Service Account",
+				"Could not read service account: "+err.Error(),
+			)
+			return
+		}
+	} else {
+		state.UUID = types.StringValue(sa.UUID)
+		state.SPN = types.StringValue(sa.SPN)
+		state.Exists = types.BoolValue(true)
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -17,6 +18,8 @@ import (
 var (
 	_ resource.Resource                = (*serviceAccountResource)(nil)
 	_ resource.ResourceWithImportState = (*serviceAccountResource)(nil)
+	_ resource.ResourceWithIdentity    = (*serviceAccountResource)(nil)
+	_ resource.ResourceWithModifyPlan  = (*serviceAccountResource)(nil)
 )
 
 func NewServiceAccountResource() resource.Resource {
@@ -28,8 +31,10 @@ type serviceAccountResource struct {
 }
 
 type serviceAccountResourceModel struct {
-	ID       types.String `tfsdk:"id"`
-	APIToken types.String `tfsdk:"api_token"`
+	ID                 types.String `tfsdk:"id"`
+	UUID               types.String `tfsdk:"uuid"`
+	APIToken           types.String `tfsdk:"api_token"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
 }
 
 func (r *serviceAccountResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
@@ -63,22 +68,33 @@ Store it securely immediately after creation.`,
 
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{
-				MarkdownDescription: "Unique identifier for the service account. Cannot be changed after creation.",
-				Required:            true,
+				MarkdownDescription: "Unique identifier for the service account. " +
+					"Changing this renames the account in place; it keeps its UUID and API tokens.",
+				Required: true,
+			},
+			"uuid": schema.StringAttribute{
+				MarkdownDescription: "Immutable UUID of the service account, used to track the account across renames.",
+				Computed:            true,
 				PlanModifiers: []planmodifier.String{
-					stringplanmodifier.RequiresReplace(),
+					stringplanmodifier.UseStateForUnknown(),
 				},
 			},
 			"api_token": schema.StringAttribute{
 				MarkdownDescription: "API token for the service account. **Only available during creation.** " +
-					"Store this token securely as it cannot be retrieved later.",
+					"Store this token securely as it cannot be retrieved later. It is stored in state; use the " +
+					"`kanidm_service_account_api_token` ephemeral resource to avoid that.",
 				Computed:  true,
 				Sensitive: true,
 			},
+			"deletion_protection": deletionProtectionAttribute(),
 		},
 	}
 }
 
+func (r *serviceAccountResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = entryIdentitySchema()
+}
+
 func (r *serviceAccountResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
@@ -96,6 +112,10 @@ func (r *serviceAccountResource) Configure(_ context.Context, req resource.Confi
 	r.client = c
 }
 
+func (r *serviceAccountResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPlanProtection(ctx, r.client, req, resp, entryNamePaths)
+}
+
 func (r *serviceAccountResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
 	var plan serviceAccountResourceModel
 	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
@@ -107,18 +127,30 @@ func (r *serviceAccountResource) Create(ctx context.Context, req resource.Create
 		"id": plan.ID.ValueString(),
 	})
 
-	// Create the service account (this also generates an initial API token)
-	sa, err := r.client.CreateServiceAccount(ctx, plan.ID.ValueString())
+	// Create the service account, or revive it from the recycle bin (this
+	// also generates an initial API token)
+	sa, err := r.createServiceAccount(ctx, plan.ID.ValueString(), &resp.Diagnostics)
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error Creating Service Account", "Could not create service account: ", err, map[string]path.Path{
+			client.ErrCodeAttributeUniqueness: path.Root("id"),
+			client.ErrCodeValueDenyName:       path.Root("id"),
+		})
+		return
+	}
+
+	// Read back the service account to get its UUID
+	createdSA, err := r.client.GetServiceAccount(ctx, sa.ID)
 	if err != nil {
 		resp.Diagnostics.AddError(
-			"Error Creating Service Account",
-			"Could not create service account: "+err.Error(),
+			"Error Reading Service Account",
+			"Service account was created but could not be read back: "+err.Error(),
 		)
 		return
 	}
 
 	// Map response to state
-	plan.ID = types.StringValue(sa.ID)
+	plan.ID = types.StringValue(createdSA.ID)
+	plan.UUID = types.StringValue(createdSA.UUID)
 	plan.APIToken = types.StringValue(sa.APIToken)
 
 	tflog.Debug(ctx, "Service account created successfully", map[string]any{
@@ -126,6 +158,32 @@ func (r *serviceAccountResource) Create(ctx context.Context, req resource.Create
 	})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	setEntryIdentity(ctx, resp.Identity, plan.UUID, &resp.Diagnostics)
+}
+
+// createServiceAccount creates the service account, or revives a recycled
+// service account with the same name when the provider deletion_mode is
+// "recycle". A revived service account keeps its UUID, memberships and
+// existing API tokens, and is issued a new token for api_token.
+func (r *serviceAccountResource) createServiceAccount(ctx context.Context, name string, diags *diag.Diagnostics) (*client.ServiceAccount, error) {
+	revived, err := reviveRecycled(ctx, r.client, client.EntryKindServiceAccount, name, diags)
+	if err != nil {
+		return nil, err
+	}
+	if !revived {
+		return r.client.CreateServiceAccount(ctx, name)
+	}
+
+	tflog.Info(ctx, "Revived service account from the recycle bin", map[string]any{
+		"id": name,
+	})
+
+	token, err := r.client.GenerateServiceAccountToken(ctx, name, "terraform-managed", nil, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &client.ServiceAccount{ID: name, APIToken: token}, nil
 }
 
 func (r *serviceAccountResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -139,8 +197,8 @@ func (r *serviceAccountResource) Read(ctx context.Context, req resource.ReadRequ
 		"id": state.ID.ValueString(),
 	})
 
-	// Get current service account from API
-	sa, err := r.client.GetServiceAccount(ctx, state.ID.ValueString())
+	// Get current service account from API, by UUID once known so renames are followed
+	sa, err := r.client.GetServiceAccount(ctx, entryRef(state.UUID, state.ID))
 	if err != nil {
 		if errors.Is(err, client.ErrNotFound) {
 			tflog.Warn(ctx, "Service account not found, removing from state", map[string]any{
@@ -159,9 +217,13 @@ func (r *serviceAccountResource) Read(ctx context.Context, req resource.ReadRequ
 
 	// Update state with current values
 	state.ID = types.StringValue(sa.ID)
+	state.UUID = types.StringValue(sa.UUID)
 	// API token is write-only and cannot be read back, preserve existing state value
 
+	state.DeletionProtection = deletionProtectionValue(state.DeletionProtection)
+
 	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	setEntryIdentity(ctx, resp.Identity, state.UUID, &resp.Diagnostics)
 }
 
 func (r *serviceAccountResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
@@ -177,8 +239,23 @@ func (r *serviceAccountResource) Update(ctx context.Context, req resource.Update
 		"id": plan.ID.ValueString(),
 	})
 
-	// Service accounts have no updatable attributes (ID requires replacement)
-	// Just preserve state values
+	// The only updatable attribute is the name, which is renamed in place
+	if !plan.ID.Equal(state.ID) {
+		tflog.Debug(ctx, "Renaming service account", map[string]any{
+			"from": state.ID.ValueString(),
+			"to":   plan.ID.ValueString(),
+		})
+		if err := r.client.RenameEntry(ctx, client.EntryKindServiceAccount, entryRef(state.UUID, state.ID), plan.ID.ValueString()); err != nil {
+			addAPIError(&resp.Diagnostics, "Error Renaming Service Account", "Could not rename service account: ", err, map[string]path.Path{
+				client.ErrCodeAttributeUniqueness: path.Root("id"),
+				client.ErrCodeValueDenyName:       path.Root("id"),
+			})
+			return
+		}
+	}
+
+	// Preserve state values that cannot be read back
+	plan.UUID = state.UUID
 	plan.APIToken = state.APIToken
 
 	tflog.Debug(ctx, "Service account updated successfully", map[string]any{
@@ -186,6 +263,7 @@ func (r *serviceAccountResource) Update(ctx context.Context, req resource.Update
 	})
 
 	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	setEntryIdentity(ctx, resp.Identity, plan.UUID, &resp.Diagnostics)
 }
 
 func (r *serviceAccountResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -199,8 +277,13 @@ func (r *serviceAccountResource) Delete(ctx context.Context, req resource.Delete
 		"id": state.ID.ValueString(),
 	})
 
+	checkDeletionProtection(ctx, r.client, req.State, entryNamePaths, "destroyed", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
 	// Delete the service account
-	if err := r.client.DeleteServiceAccount(ctx, state.ID.ValueString()); err != nil {
+	if err := r.client.DeleteServiceAccount(ctx, entryRef(state.UUID, state.ID)); err != nil {
 		if errors.Is(err, client.ErrNotFound) {
 			tflog.Warn(ctx, "Service account not found during delete, removing from state", map[string]any{
 				"id": state.ID.ValueString(),
@@ -221,8 +304,9 @@ func (r *serviceAccountResource) Delete(ctx context.Context, req resource.Delete
 }
 
 func (r *serviceAccountResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	// Use the ID directly as the import identifier
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	// Accept the account name, the UUID or an identity with the UUID; Read
+	// resolves each of them and replaces the id with the current account name
+	importEntryState(ctx, path.Root("id"), req, resp)
 
 	tflog.Debug(ctx, "Imported service account", map[string]any{
 		"id": req.ID,
@@ -0,0 +1,506 @@
+package provider
+
+import (
+	"context"
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ssoriche/terraform-provider-kanidm/internal/client"
+)
+
+const (
+	// defaultSyncTokenLabel labels sync tokens issued by the provider
+	defaultSyncTokenLabel = "terraform-managed"
+
+	// syncAccountDestroyFinalise and syncAccountDestroyTerminate are the
+	// values of destroy_action
+	syncAccountDestroyFinalise  = "finalise"
+	syncAccountDestroyTerminate = "terminate"
+)
+
+// Ensure the implementation satisfies the required interfaces
+var (
+	_ resource.Resource                   = (*syncAccountResource)(nil)
+	_ resource.ResourceWithImportState    = (*syncAccountResource)(nil)
+	_ resource.ResourceWithIdentity       = (*syncAccountResource)(nil)
+	_ resource.ResourceWithValidateConfig = (*syncAccountResource)(nil)
+	_ resource.ResourceWithModifyPlan     = (*syncAccountResource)(nil)
+)
+
+// NewSyncAccountResource creates a new sync account resource
+func NewSyncAccountResource() resource.Resource {
+	return &syncAccountResource{}
+}
+
+// syncAccountResource is the resource implementation
+type syncAccountResource struct {
+	client *client.Client
+}
+
+// syncAccountResourceModel describes the resource data model
+type syncAccountResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	UUID               types.String `tfsdk:"uuid"`
+	Description        types.String `tfsdk:"description"`
+	SyncYieldAuthority types.Set    `tfsdk:"sync_yield_authority"`
+	GenerateToken      types.Bool   `tfsdk:"generate_token"`
+	TokenLabel         types.String `tfsdk:"token_label"`
+	TokenVersion       types.Int64  `tfsdk:"token_version"`
+	Token              types.String `tfsdk:"token"`
+	DestroyAction      types.String `tfsdk:"destroy_action"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
+}
+
+// Metadata returns the resource type name
+func (r *syncAccountResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_sync_account"
+}
+
+// Schema defines the schema for the resource
+func (r *syncAccountResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: `Manages a Kanidm sync account.
+
+Sync accounts let SCIM and LDAP sync tools, such as ` + "`kanidm-ipa-sync`" + ` or ` + "`kanidm-ldap-sync`" + `,
+import entries from an external identity source. The external source owns the synchronised
+attributes, except those listed in ` + "`sync_yield_authority`" + `, which are managed in Kanidm.
+
+## Example Usage
+
+` + "```hcl" + `
+resource "kanidm_sync_account" "hr" {
+  id          = "hr-sync"
+  description = "Import from the HR system"
+
+  # Let users manage their own credentials and SSH keys in Kanidm
+  sync_yield_authority = ["legalname", "ssh_publickey"]
+
+  generate_token = true
+}
+
+output "hr_sync_token" {
+  value     = kanidm_sync_account.hr.token
+  sensitive = true
+}
+` + "```" + `
+
+**Important:** Destroying the resource finalises the sync account by default, turning the
+synchronised entries into regular Kanidm entries. Set ` + "`destroy_action = \"terminate\"`" + ` to delete
+them instead.`,
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Name of the sync account. " +
+					"Changing this renames the account in place; it keeps its UUID and synchronised entries.",
+				Required: true,
+			},
+			"uuid": schema.StringAttribute{
+				MarkdownDescription: "Immutable UUID of the sync account, used to track the account across renames.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"description": schema.StringAttribute{
+				MarkdownDescription: "Description of the sync account.",
+				Optional:            true,
+			},
+			"sync_yield_authority": schema.SetAttribute{
+				MarkdownDescription: "Attributes of synchronised entries that Kanidm owns instead of the external source, " +
+					"e.g. `legalname` or `ssh_publickey`. The sync tool no longer overwrites these attributes.",
+				Optional:    true,
+				ElementType: types.StringType,
+			},
+			"generate_token": schema.BoolAttribute{
+				MarkdownDescription: "Whether to issue the token the sync tool authenticates with. Setting this to `false` " +
+					"revokes the token. Defaults to `false`.",
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+			},
+			"token_label": schema.StringAttribute{
+				MarkdownDescription: "Label of the sync token. Changing it issues a new token. Defaults to `" + defaultSyncTokenLabel + "`.",
+				Optional:            true,
+				Computed:            true,
+				Default:             stringdefault.StaticString(defaultSyncTokenLabel),
+			},
+			"token_version": schema.Int64Attribute{
+				MarkdownDescription: "Change this value to issue a new `token` while `generate_token` is `true`. " +
+					"A sync account has a single token, so the previous one is revoked.",
+				Optional: true,
+			},
+			"token": schema.StringAttribute{
+				MarkdownDescription: "The sync token (generated when `generate_token` is `true`). **Computed value only.** " +
+					"Kanidm does not return it again, so it is kept in state; store it in a secret manager.",
+				Computed:  true,
+				Sensitive: true,
+			},
+			"destroy_action": schema.StringAttribute{
+				MarkdownDescription: "What happens to the synchronised entries when the resource is destroyed: `finalise` " +
+					"converts them into regular Kanidm entries, `terminate` deletes them. Defaults to `finalise`.",
+				Optional: true,
+				Computed: true,
+				Default:  stringdefault.StaticString(syncAccountDestroyFinalise),
+			},
+			"deletion_protection": deletionProtectionAttribute(),
+		},
+	}
+}
+
+// IdentitySchema defines the resource identity, keyed on the entry UUID
+func (r *syncAccountResource) IdentitySchema(_ context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	resp.IdentitySchema = entryIdentitySchema()
+}
+
+// Configure adds the provider configured client to the resource
+func (r *syncAccountResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			"Expected *client.Client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	r.client = c
+}
+
+// ValidateConfig checks the destroy action
+func (r *syncAccountResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var destroyAction types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("destroy_action"), &destroyAction)...)
+	if resp.Diagnostics.HasError() || destroyAction.IsNull() || destroyAction.IsUnknown() {
+		return
+	}
+
+	switch destroyAction.ValueString() {
+	case syncAccountDestroyFinalise, syncAccountDestroyTerminate:
+	default:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("destroy_action"),
+			"Invalid Destroy Action",
+			"destroy_action must be \""+syncAccountDestroyFinalise+"\" or \""+syncAccountDestroyTerminate+"\".",
+		)
+	}
+}
+
+// ModifyPlan refuses to plan the destruction of a protected sync account
+func (r *syncAccountResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPlanProtection(ctx, r.client, req, resp, entryNamePaths)
+}
+
+// Create creates the resource and sets the initial Terraform state
+func (r *syncAccountResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan syncAccountResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Creating sync account", map[string]any{
+		"id": plan.ID.ValueString(),
+	})
+
+	account, err := r.client.CreateSyncAccount(ctx, plan.ID.ValueString(), plan.Description.ValueString())
+	if err != nil {
+		addAPIError(&resp.Diagnostics, "Error Creating Sync Account", "Could not create sync account: ", err, map[string]path.Path{
+			client.ErrCodeAttributeUniqueness: path.Root("id"),
+			client.ErrCodeValueDenyName:       path.Root("id"),
+		})
+		return
+	}
+
+	var yieldAuthority []string
+	elementsAs(ctx, plan.SyncYieldAuthority, &yieldAuthority, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if len(yieldAuthority) > 0 {
+		tflog.Debug(ctx, "Setting yielded attributes for sync account")
+		if err := r.client.UpdateSyncAccount(ctx, account.ID, plan.Description.ValueString(), yieldAuthority); err != nil {
+			addAPIError(&resp.Diagnostics, "Error Updating Sync Account", "Sync account was created but its yielded attributes could not be set: ", err, map[string]path.Path{
+				client.ErrCodeInvalidAttribute: path.Root("sync_yield_authority"),
+			})
+			return
+		}
+	}
+
+	plan.Token = types.StringNull()
+	if plan.GenerateToken.ValueBool() {
+		tflog.Debug(ctx, "Generating sync token for sync account")
+		token, err := r.client.GenerateSyncToken(ctx, account.ID, plan.TokenLabel.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Generating Sync Token",
+				"Sync account was created but its sync token could not be generated: "+err.Error(),
+			)
+			return
+		}
+		plan.Token = types.StringValue(token)
+	}
+
+	r.readBack(ctx, account.ID, &plan, "created", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Sync account created successfully", map[string]any{
+		"id": plan.ID.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	setEntryIdentity(ctx, resp.Identity, plan.UUID, &resp.Diagnostics)
+}
+
+// Read refreshes the Terraform state with the latest data
+func (r *syncAccountResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state syncAccountResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading sync account", map[string]any{
+		"id": state.ID.ValueString(),
+	})
+
+	// Get current sync account from API, by UUID once known so renames are followed
+	account, err := r.client.GetSyncAccount(ctx, entryRef(state.UUID, state.ID))
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			tflog.Warn(ctx, "Sync account not found, removing from state", map[string]any{
+				"id": state.ID.ValueString(),
+			})
+			resp.State.RemoveResource(ctx)
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Reading Sync Account",
+			"Could not read sync account: "+err.Error(),
+		)
+		return
+	}
+
+	r.setState(ctx, account, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Input-only settings cannot be read back. Fill in their defaults when
+	// they are unset (e.g. after import) so the first plan is empty.
+	if state.GenerateToken.IsNull() {
+		state.GenerateToken = types.BoolValue(false)
+	}
+	if state.TokenLabel.IsNull() {
+		state.TokenLabel = types.StringValue(defaultSyncTokenLabel)
+	}
+	if state.DestroyAction.IsNull() {
+		state.DestroyAction = types.StringValue(syncAccountDestroyFinalise)
+	}
+	state.DeletionProtection = deletionProtectionValue(state.DeletionProtection)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+	setEntryIdentity(ctx, resp.Identity, state.UUID, &resp.Diagnostics)
+}
+
+// Update updates the resource and sets the updated Terraform state
+func (r *syncAccountResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state syncAccountResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Updating sync account", map[string]any{
+		"id": plan.ID.ValueString(),
+	})
+
+	ref := entryRef(state.UUID, state.ID)
+
+	// Rename in place if the name changed
+	if !plan.ID.Equal(state.ID) {
+		tflog.Debug(ctx, "Renaming sync account", map[string]any{
+			"from": state.ID.ValueString(),
+			"to":   plan.ID.ValueString(),
+		})
+		if err := r.client.RenameSyncAccount(ctx, ref, plan.ID.ValueString()); err != nil {
+			addAPIError(&resp.Diagnostics, "Error Renaming Sync Account", "Could not rename sync account: ", err, map[string]path.Path{
+				client.ErrCodeAttributeUniqueness: path.Root("id"),
+				client.ErrCodeValueDenyName:       path.Root("id"),
+			})
+			return
+		}
+	}
+
+	var yieldAuthority []string
+	elementsAs(ctx, plan.SyncYieldAuthority, &yieldAuthority, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.UpdateSyncAccount(ctx, ref, plan.Description.ValueString(), yieldAuthority); err != nil {
+		addAPIError(&resp.Diagnostics, "Error Updating Sync Account", "Could not update sync account: ", err, map[string]path.Path{
+			client.ErrCodeInvalidAttribute: path.Root("sync_yield_authority"),
+		})
+		return
+	}
+
+	// Issue a new token when it is first requested, or its label or version
+	// changes; revoke it when it is no longer wanted
+	reissue := !plan.GenerateToken.Equal(state.GenerateToken) ||
+		!plan.TokenLabel.Equal(state.TokenLabel) ||
+		!plan.TokenVersion.Equal(state.TokenVersion)
+	switch {
+	case plan.GenerateToken.ValueBool() && reissue:
+		tflog.Debug(ctx, "Generating new sync token for sync account")
+		token, err := r.client.GenerateSyncToken(ctx, ref, plan.TokenLabel.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Generating Sync Token",
+				"Sync account was updated but its sync token could not be generated: "+err.Error(),
+			)
+			return
+		}
+		plan.Token = types.StringValue(token)
+	case !plan.GenerateToken.ValueBool() && state.GenerateToken.ValueBool():
+		tflog.Debug(ctx, "Revoking sync token for sync account")
+		if err := r.client.DestroySyncToken(ctx, ref); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Revoking Sync Token",
+				"Sync account was updated but its sync token could not be revoked: "+err.Error(),
+			)
+			return
+		}
+		plan.Token = types.StringNull()
+	default:
+		plan.Token = state.Token
+	}
+
+	r.readBack(ctx, ref, &plan, "updated", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Sync account updated successfully", map[string]any{
+		"id": plan.ID.ValueString(),
+	})
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	setEntryIdentity(ctx, resp.Identity, plan.UUID, &resp.Diagnostics)
+}
+
+// Delete finalises or terminates the sync account and removes the Terraform state
+func (r *syncAccountResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state syncAccountResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Deleting sync account", map[string]any{
+		"id":             state.ID.ValueString(),
+		"destroy_action": state.DestroyAction.ValueString(),
+	})
+
+	checkDeletionProtection(ctx, r.client, req.State, entryNamePaths, "destroyed", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Kanidm has no plain delete for sync accounts; the account is removed
+	// by finalising or terminating it
+	ref := entryRef(state.UUID, state.ID)
+	var err error
+	if state.DestroyAction.ValueString() == syncAccountDestroyTerminate {
+		err = r.client.TerminateSyncAccount(ctx, ref)
+	} else {
+		err = r.client.FinaliseSyncAccount(ctx, ref)
+	}
+	if err != nil {
+		if errors.Is(err, client.ErrNotFound) {
+			tflog.Warn(ctx, "Sync account not found during delete, removing from state", map[string]any{
+				"id": state.ID.ValueString(),
+			})
+			return
+		}
+
+		resp.Diagnostics.AddError(
+			"Error Deleting Sync Account",
+			"Could not "+state.DestroyAction.ValueString()+" sync account: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, "Sync account deleted successfully", map[string]any{
+		"id": state.ID.ValueString(),
+	})
+}
+
+// ImportState imports an existing sync account into Terraform state
+func (r *syncAccountResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Accept the account name, the UUID or an identity with the UUID; Read
+	// resolves each of them and replaces the id with the current name
+	importEntryState(ctx, path.Root("id"), req, resp)
+
+	tflog.Debug(ctx, "Imported sync account", map[string]any{
+		"id": req.ID,
+	})
+
+	resp.Diagnostics.AddWarning(
+		"Sync Token Not Imported",
+		"The sync token of an imported sync account cannot be read back. "+
+			"Set generate_token = true and change token_version to issue a new one.",
+	)
+}
+
+// readBack reads the sync account after a change and maps it into model
+func (r *syncAccountResource) readBack(ctx context.Context, id string, model *syncAccountResourceModel, action string, diags *diag.Diagnostics) {
+	account, err := r.client.GetSyncAccount(ctx, id)
+	if err != nil {
+		diags.AddError(
+			"Error Reading Sync Account",
+			"Sync account was "+action+" but could not be read back: "+err.Error(),
+		)
+		return
+	}
+
+	r.setState(ctx, account, model, diags)
+}
+
+// setState maps a sync account read from Kanidm into model
+func (r *syncAccountResource) setState(ctx context.Context, account *client.SyncAccount, model *syncAccountResourceModel, diags *diag.Diagnostics) {
+	model.ID = types.StringValue(account.ID)
+	model.UUID = types.StringValue(account.UUID)
+	model.Description = optionalString(account.Description, model.Description)
+
+	// An unset attribute stays null rather than becoming an empty set
+	if len(account.YieldAuthority) == 0 && model.SyncYieldAuthority.IsNull() {
+		return
+	}
+
+	yieldAuthority := account.YieldAuthority
+	if yieldAuthority == nil {
+		yieldAuthority = []string{}
+	}
+	value, d := types.SetValueFrom(ctx, types.StringType, yieldAuthority)
+	diags.Append(d...)
+	model.SyncYieldAuthority = value
+}
@@ -0,0 +1,475 @@
+package provider
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/ssoriche/terraform-provider-kanidm/internal/client"
+)
+
+// Ensure the implementation satisfies the required interfaces
+var (
+	_ resource.Resource                   = (*systemListResource)(nil)
+	_ resource.ResourceWithImportState    = (*systemListResource)(nil)
+	_ resource.ResourceWithValidateConfig = (*systemListResource)(nil)
+	_ resource.ResourceWithModifyPlan     = (*systemListResource)(nil)
+)
+
+// NewSystemPasswordBadlistResource creates a resource managing the password badlist
+func NewSystemPasswordBadlistResource() resource.Resource {
+	return &systemListResource{
+		typeName: "_system_password_badlist",
+		attr:     client.SystemAttrBadlistPassword,
+		noun:     "password badlist",
+		description: `Manages the Kanidm system-wide password badlist. Passwords on the list are rejected when
+credentials are set.`,
+		example: `resource "kanidm_system_password_badlist" "this" {
+  file = "${path.module}/badlist.txt"
+}`,
+	}
+}
+
+// NewSystemDeniedNamesResource creates a resource managing the denied names list
+func NewSystemDeniedNamesResource() resource.Resource {
+	return &systemListResource{
+		typeName: "_system_denied_names",
+		attr:     client.SystemAttrDeniedName,
+		noun:     "denied names list",
+		description: `Manages the Kanidm system-wide denied names list. Persons, groups and service accounts
+cannot be created with, or renamed to, a name on the list.`,
+		example: `resource "kanidm_system_denied_names" "this" {
+  values = ["root", "administrator", "postmaster"]
+}`,
+	}
+}
+
+// systemListResource manages a multi-valued system configuration attribute
+type systemListResource struct {
+	client *client.Client
+
+	typeName    string
+	attr        string
+	noun        string
+	description string
+	example     string
+}
+
+// systemListResourceModel describes the resource data model
+type systemListResourceModel struct {
+	ID                 types.String `tfsdk:"id"`
+	Values             types.Set    `tfsdk:"values"`
+	File               types.String `tfsdk:"file"`
+	SHA256             types.String `tfsdk:"sha256"`
+	EntryCount         types.Int64  `tfsdk:"entry_count"`
+	DeletionProtection types.Bool   `tfsdk:"deletion_protection"`
+}
+
+// Metadata returns the resource type name
+func (r *systemListResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + r.typeName
+}
+
+// Schema defines the schema for the resource
+func (r *systemListResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: r.description + `
+
+The list is managed authoritatively: entries not in the configuration are removed. Entries are
+compared case-insensitively, and only added or removed entries are sent to Kanidm, so large lists
+are not uploaded again on every apply. Use ` + "`file`" + ` for large lists to keep them out of
+state; only a hash of the entries is stored. Destroying the resource clears the list.
+
+## Example Usage
+
+` + "```hcl\n" + r.example + "\n```",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				MarkdownDescription: "Name of the system attribute holding the list.",
+				Computed:            true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"values": schema.SetAttribute{
+				MarkdownDescription: "Entries of the " + r.noun + ". Conflicts with `file`.",
+				Optional:            true,
+				ElementType:         types.StringType,
+			},
+			"file": schema.StringAttribute{
+				MarkdownDescription: "Path to a file with one entry per line. Blank lines and lines starting with `#` are ignored. " +
+					"Conflicts with `values`.",
+				Optional: true,
+			},
+			"sha256": schema.StringAttribute{
+				MarkdownDescription: "SHA-256 of the sorted, lowercased entries, used to detect changes to the file or on the server.",
+				Computed:            true,
+			},
+			"entry_count": schema.Int64Attribute{
+				MarkdownDescription: "Number of entries in the list.",
+				Computed:            true,
+			},
+			"deletion_protection": deletionProtectionAttribute(),
+		},
+	}
+}
+
+// Configure adds the provider configured client to the resource
+func (r *systemListResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	c, ok := req.ProviderData.(*client.Client)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			"Expected *client.Client. Please report this issue to the provider developers.",
+		)
+		return
+	}
+
+	r.client = c
+}
+
+// ValidateConfig checks that exactly one source of entries is configured
+func (r *systemListResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config systemListResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.Values.IsNull() && !config.File.IsNull() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("file"),
+			"Conflicting Configuration",
+			"Cannot specify both 'values' and 'file'. Choose one source for the "+r.noun+".",
+		)
+	}
+
+	if config.Values.IsNull() && config.File.IsNull() {
+		resp.Diagnostics.AddError(
+			"Missing Configuration",
+			"One of 'values' or 'file' must be specified for the "+r.noun+".",
+		)
+	}
+}
+
+// ModifyPlan refuses to plan clearing a protected list, and hashes the
+// configured entries so a changed file or list shows up as a single changed
+// hash rather than a diff of every entry
+func (r *systemListResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	checkPlanProtection(ctx, r.client, req, resp, nil)
+	if req.Plan.Raw.IsNull() || resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan systemListResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.Values.IsUnknown() || plan.File.IsUnknown() {
+		return
+	}
+
+	entries, ok := r.desired(ctx, &plan, &resp.Diagnostics)
+	if !ok {
+		return
+	}
+
+	plan.SHA256 = types.StringValue(hashEntries(entries))
+	plan.EntryCount = types.Int64Value(int64(len(entries)))
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// Create replaces the list with the configured entries
+func (r *systemListResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan systemListResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Creating system list", map[string]any{
+		"attribute": r.attr,
+	})
+
+	r.sync(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the list from Kanidm
+func (r *systemListResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state systemListResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Reading system list", map[string]any{
+		"attribute": r.attr,
+	})
+
+	current, err := r.client.GetSystemAttribute(ctx, r.attr)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading System List",
+			"Could not read the "+r.noun+": "+err.Error(),
+		)
+		return
+	}
+
+	r.setState(ctx, current, &state, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	state.DeletionProtection = deletionProtectionValue(state.DeletionProtection)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update adds and removes entries so the list matches the configuration
+func (r *systemListResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan systemListResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Debug(ctx, "Updating system list", map[string]any{
+		"attribute": r.attr,
+	})
+
+	r.sync(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete clears the list
+func (r *systemListResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	tflog.Debug(ctx, "Clearing system list", map[string]any{
+		"attribute": r.attr,
+	})
+
+	checkDeletionProtection(ctx, r.client, req.State, nil, "destroyed", &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.PurgeSystemAttribute(ctx, r.attr); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Deleting System List",
+			"Could not clear the "+r.noun+": "+err.Error(),
+		)
+	}
+}
+
+// ImportState adopts the current list into values. The import ID is ignored.
+func (r *systemListResource) ImportState(ctx context.Context, _ resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resp.Diagnostics.Append(resp.State.Set(ctx, &systemListResourceModel{
+		ID:                 types.StringValue(r.attr),
+		Values:             types.SetValueMust(types.StringType, nil),
+		File:               types.StringNull(),
+		SHA256:             types.StringNull(),
+		EntryCount:         types.Int64Null(),
+		DeletionProtection: types.BoolValue(false),
+	})...)
+}
+
+// sync adds the configured entries missing from Kanidm and removes the ones
+// that are no longer configured, then updates the model from the result
+func (r *systemListResource) sync(ctx context.Context, plan *systemListResourceModel, diags *diag.Diagnostics) {
+	desired, ok := r.desired(ctx, plan, diags)
+	if !ok {
+		return
+	}
+
+	current, err := r.client.GetSystemAttribute(ctx, r.attr)
+	if err != nil {
+		diags.AddError(
+			"Error Reading System List",
+			"Could not read the "+r.noun+": "+err.Error(),
+		)
+		return
+	}
+
+	wanted := make(map[string]bool, len(desired))
+	for _, entry := range desired {
+		wanted[entry] = true
+	}
+
+	var remove []string
+	present := make(map[string]bool, len(current))
+	for _, value := range current {
+		entry := normalizeListEntry(value)
+		present[entry] = true
+		if !wanted[entry] {
+			remove = append(remove, value)
+		}
+	}
+
+	var add []string
+	for _, entry := range desired {
+		if !present[entry] {
+			add = append(add, entry)
+		}
+	}
+
+	tflog.Debug(ctx, "Synchronising system list", map[string]any{
+		"attribute": r.attr,
+		"add":       len(add),
+		"remove":    len(remove),
+	})
+
+	if len(remove) > 0 {
+		if err := r.client.RemoveSystemAttributeValues(ctx, r.attr, remove); err != nil {
+			diags.AddError("Error Updating System List", "Could not remove entries from the "+r.noun+": "+err.Error())
+			return
+		}
+	}
+
+	if len(add) > 0 {
+		if err := r.client.AddSystemAttributeValues(ctx, r.attr, add); err != nil {
+			addAPIError(diags, "Error Updating System List", "Could not add entries to the "+r.noun+": ", err, nil)
+			return
+		}
+	}
+
+	current, err = r.client.GetSystemAttribute(ctx, r.attr)
+	if err != nil {
+		diags.AddError(
+			"Error Reading System List",
+			"The "+r.noun+" was updated but could not be read back: "+err.Error(),
+		)
+		return
+	}
+
+	r.setState(ctx, current, plan, diags)
+}
+
+// desired returns the normalized entries configured in values or file
+func (r *systemListResource) desired(ctx context.Context, model *systemListResourceModel, diags *diag.Diagnostics) ([]string, bool) {
+	var entries []string
+	if !model.File.IsNull() {
+		var err error
+		entries, err = readListFile(model.File.ValueString())
+		if err != nil {
+			diags.AddAttributeError(path.Root("file"), "Error Reading File", "Could not read the "+r.noun+" file: "+err.Error())
+			return nil, false
+		}
+	} else {
+		elementsAs(ctx, model.Values, &entries, diags)
+		if diags.HasError() {
+			return nil, false
+		}
+	}
+
+	return normalizeListEntries(entries), true
+}
+
+// setState records the entries read from Kanidm in model. Configured values
+// keep the form they were written in when Kanidm holds the same entry.
+func (r *systemListResource) setState(ctx context.Context, current []string, model *systemListResourceModel, diags *diag.Diagnostics) {
+	entries := normalizeListEntries(current)
+
+	model.ID = types.StringValue(r.attr)
+	model.SHA256 = types.StringValue(hashEntries(entries))
+	model.EntryCount = types.Int64Value(int64(len(entries)))
+
+	if model.Values.IsNull() {
+		return
+	}
+
+	var configured []string
+	elementsAs(ctx, model.Values, &configured, diags)
+
+	forms := make(map[string]string, len(configured))
+	for _, value := range configured {
+		forms[normalizeListEntry(value)] = value
+	}
+
+	values := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if form, ok := forms[entry]; ok {
+			values = append(values, form)
+		} else {
+			values = append(values, entry)
+		}
+	}
+
+	set, d := types.SetValueFrom(ctx, types.StringType, values)
+	diags.Append(d...)
+	model.Values = set
+}
+
+// readListFile reads one entry per line, skipping blank lines and comments
+func readListFile(name string) ([]string, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	var entries []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		entries = append(entries, line)
+	}
+
+	return entries, scanner.Err()
+}
+
+// normalizeListEntry returns the form Kanidm stores a list entry in
+func normalizeListEntry(value string) string {
+	return strings.ToLower(strings.TrimSpace(value))
+}
+
+// normalizeListEntries normalizes, deduplicates and sorts entries
+func normalizeListEntries(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	entries := make([]string, 0, len(values))
+	for _, value := range values {
+		entry := normalizeListEntry(value)
+		if entry == "" || seen[entry] {
+			continue
+		}
+		seen[entry] = true
+		entries = append(entries, entry)
+	}
+
+	sort.Strings(entries)
+	return entries
+}
+
+// hashEntries returns the SHA-256 of sorted entries, one per line
+func hashEntries(entries []string) string {
+	h := sha256.New()
+	for _, entry := range entries {
+		h.Write([]byte(entry))
+		h.Write([]byte{'\n'})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}